@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const baselineVersion = 1
+
+// Baseline is a set of known issues which are not reported. Issues are
+// identified by rule, file and a fingerprint of the message and source lines
+// of the issue, so they survive unrelated changes shifting line numbers.
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a group of identical issues.
+type BaselineEntry struct {
+	Rule        string `json:"rule"`
+	Filename    string `json:"filename"`
+	Fingerprint string `json:"fingerprint"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+type baselineKey struct {
+	rule        string
+	filename    string
+	fingerprint string
+}
+
+func (e BaselineEntry) key() baselineKey {
+	return baselineKey{rule: e.Rule, filename: e.Filename, fingerprint: e.Fingerprint}
+}
+
+// NewBaseline records the issues.
+func NewBaseline(issues []*Issue, sources map[string][]byte) *Baseline {
+	entries := map[baselineKey]*BaselineEntry{}
+	keys := []baselineKey{}
+	for _, issue := range issues {
+		entry := newBaselineEntry(issue, sources)
+		if existing, ok := entries[entry.key()]; ok {
+			existing.Count++
+			continue
+		}
+
+		entries[entry.key()] = &entry
+		keys = append(keys, entry.key())
+	}
+
+	baseline := &Baseline{Version: baselineVersion, Entries: make([]BaselineEntry, 0, len(keys))}
+	for _, key := range keys {
+		baseline.Entries = append(baseline.Entries, *entries[key])
+	}
+	sort.SliceStable(baseline.Entries, func(i, j int) bool {
+		a, b := baseline.Entries[i], baseline.Entries[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	return baseline
+}
+
+func newBaselineEntry(issue *Issue, sources map[string][]byte) BaselineEntry {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s", issue.Rule.Name(), issue.Message, issueSource(issue, sources))
+
+	return BaselineEntry{
+		Rule:        issue.Rule.Name(),
+		Filename:    outputPath(issue.Range.Filename),
+		Fingerprint: hex.EncodeToString(hash.Sum(nil)),
+		Message:     issue.Message,
+		Count:       1,
+	}
+}
+
+// issueSource returns the lines covered by the issue without indentation,
+// so reformatting and moving code around do not change fingerprints.
+func issueSource(issue *Issue, sources map[string][]byte) string {
+	src, ok := sources[issue.Range.Filename]
+	if !ok {
+		return ""
+	}
+
+	lines := strings.Split(string(src), "\n")
+	from := issue.Range.Start.Line - 1
+	to := issue.Range.End.Line
+	if from < 0 || from >= len(lines) {
+		return ""
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+
+	content := make([]string, 0, to-from)
+	for _, line := range lines[from:to] {
+		content = append(content, strings.TrimSpace(line))
+	}
+
+	return strings.Join(content, "\n")
+}
+
+// LoadBaseline reads the baseline file.
+func LoadBaseline(filename string) (*Baseline, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(src, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", filename, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf(
+			"baseline %s has unsupported version %d, expected %d",
+			filename,
+			baseline.Version,
+			baselineVersion,
+		)
+	}
+
+	return &baseline, nil
+}
+
+// Write saves the baseline file.
+func (b *Baseline) Write(filename string) error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(content, '\n'), 0o644)
+}
+
+// Filter drops known issues. It returns new issues and baseline
+// entries which do not occur anymore.
+func (b *Baseline) Filter(issues []*Issue, sources map[string][]byte) ([]*Issue, []BaselineEntry) {
+	remaining := map[baselineKey]int{}
+	for _, entry := range b.Entries {
+		remaining[entry.key()] += entry.Count
+	}
+
+	reported := []*Issue{}
+	for _, issue := range issues {
+		key := newBaselineEntry(issue, sources).key()
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		reported = append(reported, issue)
+	}
+
+	fixed := []BaselineEntry{}
+	for _, entry := range b.Entries {
+		count := remaining[entry.key()]
+		if count <= 0 {
+			continue
+		}
+
+		// Entries with the same key share the remaining count.
+		entry.Count = minInt(count, entry.Count)
+		remaining[entry.key()] -= entry.Count
+		fixed = append(fixed, entry)
+	}
+
+	return reported, fixed
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Baseline(t *testing.T) {
+	t.Parallel()
+
+	dir, args := prepareRun(t, map[string]string{
+		mainFilename: `resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = "${var.second}"
+}
+`,
+	}, []string{"check", "--config", "{dir}/custom.hcl", "--write-baseline", "{dir}/baseline.json", "{dir}"})
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, ExitOK, Run("dev", args, &stdout, &stderr), stderr.String())
+	require.Empty(t, stdout.String())
+
+	baseline, err := LoadBaseline(filepath.Join(dir, "baseline.json"))
+	require.NoError(t, err)
+	require.Len(t, baseline.Entries, 2)
+
+	// Issues move down, the second one is fixed and a new one appears.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mainFilename), []byte(`resource "azurerm_resource_group" "zero" {
+  name = "zero"
+}
+
+resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = var.second
+}
+
+resource "azurerm_resource_group" "third" {
+  name = "${var.third}"
+}
+`), 0o644))
+
+	args = []string{
+		"check",
+		"--config", filepath.Join(dir, "custom.hcl"),
+		"--baseline", filepath.Join(dir, "baseline.json"),
+		dir,
+	}
+	stdout.Reset()
+	stderr.Reset()
+	require.Equal(t, ExitIssues, Run("dev", args, &stdout, &stderr), stderr.String())
+	require.Equal(
+		t,
+		`main.tf:14:10: warning: Interpolation-only expressions are deprecated, `+
+			`use the expression without "${}" (dodo_legacy_syntax)
+`,
+		stripDir(stdout.String(), dir),
+	)
+	require.Equal(
+		t,
+		`main.tf: baseline issue no longer occurs: Interpolation-only expressions are deprecated, `+
+			`use the expression without "${}" (dodo_legacy_syntax)
+`,
+		stripDir(stderr.String(), dir),
+	)
+}
+
+func Test_BaselineFix(t *testing.T) {
+	t.Parallel()
+
+	dir, args := prepareRun(t, map[string]string{
+		mainFilename: `resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+`,
+	}, []string{"check", "--config", "{dir}/custom.hcl", "--write-baseline", "{dir}/baseline.json", "{dir}"})
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, ExitOK, Run("dev", args, &stdout, &stderr), stderr.String())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mainFilename), []byte(`resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = "${var.second}"
+}
+`), 0o644))
+
+	args = []string{
+		"fix",
+		"--config", filepath.Join(dir, "custom.hcl"),
+		"--baseline", filepath.Join(dir, "baseline.json"),
+		dir,
+	}
+	stdout.Reset()
+	stderr.Reset()
+	require.Equal(t, ExitOK, Run("dev", args, &stdout, &stderr), stderr.String())
+	require.Empty(t, stdout.String())
+	require.Empty(t, stderr.String())
+
+	fixed, err := os.ReadFile(filepath.Join(dir, mainFilename))
+	require.NoError(t, err)
+	require.Equal(t, `resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = var.second
+}
+`, string(fixed))
+}
+
+func Test_BaselineCounts(t *testing.T) {
+	t.Parallel()
+
+	report := testReport()
+	duplicated := append(report.Issues, report.Issues[0])
+
+	baseline := NewBaseline(duplicated, report.Sources)
+	require.Len(t, baseline.Entries, 1)
+	require.Equal(t, 2, baseline.Entries[0].Count)
+
+	reported, fixed := baseline.Filter(append(duplicated, report.Issues[0]), report.Sources)
+	require.Len(t, reported, 1)
+	require.Empty(t, fixed)
+
+	reported, fixed = baseline.Filter(report.Issues, report.Sources)
+	require.Empty(t, reported)
+	require.Len(t, fixed, 1)
+	require.Equal(t, 1, fixed[0].Count)
+}
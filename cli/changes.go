@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+// ChangedLines are lines added or modified by a change keyed by
+// slash-separated file paths. Files touched only by deletions have no lines.
+type ChangedLines map[string]map[int]bool
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// ParseUnifiedDiff reads changed lines from a unified diff, e.g. produced by
+// git diff. Paths are taken from "+++" headers with "b/" prefix stripped.
+func ParseUnifiedDiff(r io.Reader) (ChangedLines, error) {
+	changes := ChangedLines{}
+
+	var lines map[int]bool
+	line, remaining := 0, 0
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if remaining == 0 {
+			switch {
+			case strings.HasPrefix(text, "+++ "):
+				path := diffPath(strings.TrimPrefix(text, "+++ "))
+				if path == "" {
+					lines = nil
+					continue
+				}
+				if _, ok := changes[path]; !ok {
+					changes[path] = map[int]bool{}
+				}
+				lines = changes[path]
+			case strings.HasPrefix(text, "@@ "):
+				match := hunkHeaderRegexp.FindStringSubmatch(text)
+				if match == nil {
+					return nil, fmt.Errorf("malformed hunk header: %s", text)
+				}
+				line, _ = strconv.Atoi(match[1])
+				remaining = 1
+				if match[2] != "" {
+					remaining, _ = strconv.Atoi(match[2])
+				}
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+"):
+			if lines != nil {
+				lines[line] = true
+			}
+			line++
+			remaining--
+		case strings.HasPrefix(text, " "), text == "":
+			line++
+			remaining--
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// diffPath returns the path of "+++" header, empty for deleted files.
+func diffPath(header string) string {
+	if i := strings.IndexByte(header, '\t'); i != -1 {
+		header = header[:i]
+	}
+	if header == "/dev/null" {
+		return ""
+	}
+	if unquoted, err := strconv.Unquote(header); err == nil {
+		header = unquoted
+	}
+
+	return filepath.ToSlash(filepath.Clean(strings.TrimPrefix(header, "b/")))
+}
+
+// LoadDiff reads changed lines from the diff file, "-" is stdin.
+func LoadDiff(filename string, stdin io.Reader) (ChangedLines, error) {
+	if filename == "-" {
+		return ParseUnifiedDiff(stdin)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseUnifiedDiff(f)
+}
+
+// GitChangedLines returns lines changed in the working tree relative to the
+// revision. Untracked files which are not ignored are changed entirely.
+// Paths are relative to the current directory like issue filenames.
+func GitChangedLines(rev string) (ChangedLines, error) {
+	diff, err := gitOutput("diff", "--relative", "--no-color", "--no-ext-diff", "-U0", rev, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", rev, err)
+	}
+	changes, err := ParseUnifiedDiff(bytes.NewReader(diff))
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := gitOutput("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+	for _, path := range strings.Split(string(untracked), "\x00") {
+		if path == "" {
+			continue
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		lines := map[int]bool{}
+		for line := 1; line <= bytes.Count(src, []byte("\n"))+1; line++ {
+			lines[line] = true
+		}
+		changes[filepath.ToSlash(filepath.Clean(path))] = lines
+	}
+
+	return changes, nil
+}
+
+func gitOutput(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// Filter keeps issues of touched files intersecting changed lines. Issues of
+// the whole file are kept whenever the file is touched.
+func (c ChangedLines) Filter(issues []*Issue) []*Issue {
+	filtered := []*Issue{}
+	for _, issue := range issues {
+		lines, ok := c[rules.RelativePath(issue.Range.Filename)]
+		if !ok {
+			continue
+		}
+		if issue.FileScoped || intersects(lines, issue.Range.Start.Line, issue.Range.End.Line) {
+			filtered = append(filtered, issue)
+		}
+	}
+
+	return filtered
+}
+
+func intersects(lines map[int]bool, from, to int) bool {
+	for line := from; line <= to; line++ {
+		if lines[line] {
+			return true
+		}
+	}
+
+	return false
+}
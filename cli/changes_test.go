@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := `diff --git a/main.tf b/main.tf
+index 1111111..2222222 100644
+--- a/main.tf
++++ b/main.tf
+@@ -1,4 +1,5 @@
+ resource "azurerm_resource_group" "example" {
+-  name = "old"
++  name     = "new"
++  location = "westeurope"
+ }
+ 
+@@ -10,0 +12,1 @@ resource
++# comment
+diff --git a/removed.tf b/removed.tf
+deleted file mode 100644
+--- a/removed.tf
++++ /dev/null
+@@ -1 +0,0 @@
+-locals {}
+diff --git a/variables.tf b/variables.tf
+--- a/variables.tf
++++ b/variables.tf
+@@ -3,1 +2,0 @@
+-variable "unused" {}
+`
+
+	changes, err := ParseUnifiedDiff(strings.NewReader(diff))
+	require.NoError(t, err)
+	require.Equal(t, ChangedLines{
+		"main.tf":      {2: true, 3: true, 12: true},
+		"variables.tf": {},
+	}, changes)
+}
+
+func Test_ChangedLinesFilter(t *testing.T) {
+	t.Parallel()
+
+	rule := testReport().Rules[0]
+	issue := func(filename string, from, to int, fileScoped bool) *Issue {
+		return &Issue{
+			Rule:    rule,
+			Message: "message",
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: from, Column: 1},
+				End:      hcl.Pos{Line: to, Column: 1},
+			},
+			FileScoped: fileScoped,
+		}
+	}
+
+	changes := ChangedLines{
+		"main.tf":            {3: true},
+		"modules/db/main.tf": {},
+	}
+	issues := []*Issue{
+		issue("main.tf", 1, 2, false),
+		issue("main.tf", 2, 4, false),
+		issue("./main.tf", 3, 3, false),
+		issue("main.tf", 1, 1, true),
+		issue(filepath.Join("modules", "db", "main.tf"), 1, 1, true),
+		issue(filepath.Join("modules", "db", "main.tf"), 1, 1, false),
+		issue("variables.tf", 3, 3, true),
+	}
+
+	require.Equal(t, []*Issue{issues[1], issues[2], issues[3], issues[4]}, changes.Filter(issues))
+}
+
+func Test_RunDiff(t *testing.T) {
+	t.Parallel()
+
+	dir, args := prepareRun(t, map[string]string{
+		mainFilename: `resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = "${var.second}"
+}`,
+		"outputs.tf": `output "name" {
+  value = "${var.name}"
+}
+`,
+	}, []string{"check", "--config", "{dir}/custom.hcl", "--diff", "{dir}/changes.diff", "{dir}"})
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	path, err := filepath.Rel(wd, filepath.Join(dir, mainFilename))
+	require.NoError(t, err)
+	diff := `--- a/` + filepath.ToSlash(path) + `
++++ b/` + filepath.ToSlash(path) + `
+@@ -6 +6 @@
+-  name = var.second
++  name = "${var.second}"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changes.diff"), []byte(diff), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, ExitIssues, Run("dev", args, &stdout, &stderr), stderr.String())
+	require.Equal(
+		t,
+		`main.tf:6:10: warning: Interpolation-only expressions are deprecated, `+
+			`use the expression without "${}" (dodo_legacy_syntax)
+main.tf:7:0: warning: There is no empty line at the end of file (dodo_file_content)
+`,
+		stripDir(stdout.String(), dir),
+	)
+}
+
+// Test_RunFixNewFromRev is not parallel, it changes the working directory
+// to diff against a revision of a temporary repository.
+func Test_RunFixNewFromRev(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, args := prepareRun(t, map[string]string{
+		mainFilename: `resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = "${var.second}"
+}
+`,
+	}, []string{"fix", "--config", "{dir}/custom.hcl", "--new-from-rev", "HEAD", "{dir}"})
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(wd))
+	})
+
+	for _, gitArgs := range [][]string{
+		{"init", "--quiet"},
+		{"add", mainFilename},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init"},
+	} {
+		output, err := exec.Command("git", gitArgs...).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	require.NoError(t, os.WriteFile(mainFilename, []byte(`resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = "${var.changed}"
+}
+`), 0o644))
+	require.NoError(t, os.WriteFile("outputs.tf", []byte(`output "name" {
+  value = "${var.name}"
+}
+`), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, ExitOK, Run("dev", args, &stdout, &stderr), stderr.String())
+	require.Empty(t, stdout.String())
+
+	fixed, err := os.ReadFile(mainFilename)
+	require.NoError(t, err)
+	require.Equal(t, `resource "azurerm_resource_group" "first" {
+  name = "${var.first}"
+}
+
+resource "azurerm_resource_group" "second" {
+  name = var.changed
+}
+`, string(fixed))
+
+	fixed, err = os.ReadFile("outputs.tf")
+	require.NoError(t, err)
+	require.Equal(t, `output "name" {
+  value = var.name
+}
+`, string(fixed))
+}
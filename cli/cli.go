@@ -0,0 +1,366 @@
+// Package cli runs dodo rules without TFLint, e.g. from pre-commit hooks and
+// editors:
+//
+//	tflint-ruleset-dodo check [--format text|json|checkstyle|sarif|github|diff] [paths...]
+//	tflint-ruleset-dodo fix [paths...]
+//
+// Both commands may report and fix only issues on lines changed relative to
+// a git revision with --new-from-rev or a unified diff file with --diff.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+// Exit codes follow TFLint ones.
+const (
+	ExitOK     = 0
+	ExitError  = 1
+	ExitIssues = 2
+)
+
+// maxFixPasses limits rechecks after fixing, because fixes of one rule
+// may produce new issues of another one.
+const maxFixPasses = 10
+
+const usage = `Usage: tflint-ruleset-dodo <command> [options] [paths...]
+
+Commands:
+  check  Report issues in Terraform files
+  fix    Apply autofixes and report remaining issues
+
+Paths are files or directories, the current directory by default.
+`
+
+type options struct {
+	format        string
+	config        string
+	baseline      string
+	writeBaseline string
+	diff          string
+	newFromRev    string
+	paths         []string
+}
+
+// Run executes the command and returns the exit code.
+func Run(version string, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return ExitError
+	}
+
+	command := args[0]
+	opts, err := parseOptions(command, args[1:], stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitError
+	}
+
+	var run func(*session, []string) (*Report, error)
+	switch command {
+	case "check":
+		run = check
+	case "fix":
+		run = fix
+	default:
+		fmt.Fprintf(stderr, "unknown command \"%s\"\n\n%s\n", command, usage)
+		return ExitError
+	}
+
+	s, err := newSession(version, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitError
+	}
+	report, err := run(s, opts.paths)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitError
+	}
+
+	sortIssues(report.Issues)
+	if opts.writeBaseline != "" {
+		if err := NewBaseline(report.Issues, report.Sources).Write(opts.writeBaseline); err != nil {
+			fmt.Fprintln(stderr, err)
+			return ExitError
+		}
+		fmt.Fprintf(stderr, "%d issues written to baseline %s\n", len(report.Issues), opts.writeBaseline)
+		return ExitOK
+	}
+
+	var fixed []BaselineEntry
+	report.Issues, fixed = s.filter(report.Issues)
+	for _, entry := range fixed {
+		fmt.Fprintf(
+			stderr,
+			"%s: baseline issue no longer occurs: %s (%s)\n",
+			entry.Filename,
+			entry.Message,
+			entry.Rule,
+		)
+	}
+
+	if err := formatters[opts.format](stdout, report); err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitError
+	}
+	if len(report.Issues) != 0 {
+		return ExitIssues
+	}
+
+	return ExitOK
+}
+
+func parseOptions(command string, args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(
+		&opts.format,
+		"format",
+		FormatText,
+		fmt.Sprintf("output format: %s", strings.Join(formatNames(), ", ")),
+	)
+	flags.StringVar(&opts.config, "config", "", "TFLint config file, "+defaultConfigFilename+" if exists")
+	flags.StringVar(&opts.baseline, "baseline", "", "baseline file with known issues which are not reported")
+	flags.StringVar(&opts.writeBaseline, "write-baseline", "", "write all current issues to the baseline file")
+	flags.StringVar(&opts.diff, "diff", "", "report only issues on lines changed by the unified diff file, - for stdin")
+	flags.StringVar(&opts.newFromRev, "new-from-rev", "", "report only issues on lines changed since the git revision")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if opts.diff != "" && opts.newFromRev != "" {
+		return nil, errors.New("--diff and --new-from-rev cannot be used together")
+	}
+
+	if _, ok := formatters[opts.format]; !ok {
+		return nil, fmt.Errorf(
+			"unknown format \"%s\", available formats: %s",
+			opts.format,
+			strings.Join(formatNames(), ", "),
+		)
+	}
+
+	opts.paths = flags.Args()
+	if len(opts.paths) == 0 {
+		opts.paths = []string{"."}
+	}
+
+	return opts, nil
+}
+
+type session struct {
+	version string
+	config  *Config
+	ruleSet *rules.RuleSet
+	sources map[string][]byte
+	// baseline holds known issues which are neither reported nor fixed,
+	// it is nil without --baseline.
+	baseline *Baseline
+	// changes are lines changed by --diff or since --new-from-rev,
+	// nil if all issues are reported.
+	changes    ChangedLines
+	newFromRev string
+}
+
+func newSession(version string, opts *options) (*session, error) {
+	config, err := LoadConfig(opts.config)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleSet := rules.NewRuleSet(version)
+	if err := ruleSet.ApplyConfig(config.tflintConfig()); err != nil {
+		return nil, err
+	}
+
+	s := &session{
+		version: version,
+		config:  config,
+		ruleSet: ruleSet,
+		sources: map[string][]byte{},
+	}
+	if opts.baseline != "" && opts.writeBaseline == "" {
+		if s.baseline, err = LoadBaseline(opts.baseline); err != nil {
+			return nil, err
+		}
+	}
+	if opts.diff != "" {
+		if s.changes, err = LoadDiff(opts.diff, os.Stdin); err != nil {
+			return nil, err
+		}
+	}
+	if opts.newFromRev != "" {
+		s.newFromRev = opts.newFromRev
+		if err := s.loadChanges(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// loadChanges diffs the working tree against --new-from-rev. It runs again
+// after every fix pass, because fixes shift lines changed since the revision.
+func (s *session) loadChanges() error {
+	if s.newFromRev == "" {
+		return nil
+	}
+
+	changes, err := GitChangedLines(s.newFromRev)
+	if err != nil {
+		return err
+	}
+	s.changes = changes
+
+	return nil
+}
+
+// filter drops issues which should be neither reported nor fixed. It returns
+// remaining issues and baseline entries which do not occur anymore.
+func (s *session) filter(issues []*Issue) ([]*Issue, []BaselineEntry) {
+	var fixed []BaselineEntry
+	if s.baseline != nil {
+		issues, fixed = s.baseline.Filter(issues, s.sources)
+	}
+	if s.changes != nil {
+		issues = s.changes.Filter(issues)
+	}
+
+	return issues, fixed
+}
+
+func (s *session) report(issues []*Issue) *Report {
+	return &Report{
+		Version: s.version,
+		Rules:   s.ruleSet.EnabledRules,
+		Issues:  issues,
+		Sources: s.sources,
+	}
+}
+
+// checkModule runs enabled rules against the module and returns
+// issues of the files to report.
+func (s *session) checkModule(module *Module) ([]*Issue, error) {
+	runner, err := NewRunner(module.Dir, module.Files, s.config.RuleBodies)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ruleSet.Check(runner); err != nil {
+		return nil, err
+	}
+	for filename, file := range module.Files {
+		s.sources[filename] = file.Bytes
+	}
+
+	issues := []*Issue{}
+	for _, issue := range runner.Issues {
+		if module.Reports(issue.Range.Filename) {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+func check(s *session, paths []string) (*Report, error) {
+	modules, err := LoadModules(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []*Issue{}
+	for _, module := range modules {
+		moduleIssues, err := s.checkModule(module)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, moduleIssues...)
+	}
+
+	return s.report(issues), nil
+}
+
+func fix(s *session, paths []string) (*Report, error) {
+	modules, err := LoadModules(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []*Issue{}
+	for _, module := range modules {
+		moduleIssues, err := s.fixModule(module)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, moduleIssues...)
+	}
+
+	return s.report(issues), nil
+}
+
+// fixModule applies fixes until there are none left and returns remaining
+// issues. Issues dropped by the session filter are not fixed.
+func (s *session) fixModule(module *Module) ([]*Issue, error) {
+	for pass := 0; ; pass++ {
+		issues, err := s.checkModule(module)
+		if err != nil {
+			return nil, err
+		}
+
+		fixable, _ := s.filter(issues)
+		fixes := map[string][]rules.Fix{}
+		for _, issue := range fixable {
+			if issue.Fix != nil {
+				fixes[issue.Fix.Range.Filename] = append(fixes[issue.Fix.Range.Filename], *issue.Fix)
+			}
+		}
+		if len(fixes) == 0 || pass == maxFixPasses {
+			return issues, nil
+		}
+
+		for filename, fileFixes := range fixes {
+			file, ok := module.Files[filename]
+			if !ok {
+				continue
+			}
+
+			fixed, err := rules.ApplyFixes(file.Bytes, fileFixes)
+			if err != nil {
+				return nil, err
+			}
+			if err := writeFile(filename, fixed); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := module.load(); err != nil {
+			return nil, err
+		}
+		if err := s.loadChanges(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// writeFile replaces the file content keeping its permissions.
+func writeFile(filename string, content []byte) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, content, info.Mode().Perm())
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const mainFilename = "main.tf"
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o755))
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+// formattingConfig keeps only formatting rules, so tests do not depend
+// on required providers and module structure.
+const formattingConfig = `plugin "dodo" {
+  enabled = true
+  preset  = "formatting-only"
+}
+`
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Args     []string
+		Files    map[string]string
+		Output   string
+		ExitCode int
+	}{
+		{
+			Name: "no issues",
+			Args: []string{"check", "--config", "{dir}/custom.hcl", "{dir}"},
+			Files: map[string]string{
+				mainFilename: `resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}
+`,
+			},
+			Output:   "",
+			ExitCode: ExitOK,
+		},
+		{
+			Name: "issues",
+			Args: []string{"check", "--config", "{dir}/custom.hcl", "{dir}"},
+			Files: map[string]string{
+				mainFilename: `resource "azurerm_resource_group" "example" {
+  name = "${var.name}"
+  location = "westeurope"
+}
+`,
+			},
+			Output: `main.tf:2:3: warning: Equals sign should be aligned with other attributes in the group ` +
+				`like terraform fmt does (dodo_attribute_alignment)
+main.tf:2:10: warning: Interpolation-only expressions are deprecated, ` +
+				`use the expression without "${}" (dodo_legacy_syntax)
+`,
+			ExitCode: ExitIssues,
+		},
+		{
+			Name: "rule disabled in config",
+			Args: []string{"check", "--config", "{dir}/custom.hcl", "{dir}"},
+			Files: map[string]string{
+				"custom.hcl": formattingConfig + `
+rule "dodo_attribute_alignment" {
+  enabled = false
+}
+`,
+				mainFilename: `resource "azurerm_resource_group" "example" {
+  name = "${var.name}"
+  location = "westeurope"
+}
+`,
+			},
+			Output: `main.tf:2:10: warning: Interpolation-only expressions are deprecated, ` +
+				`use the expression without "${}" (dodo_legacy_syntax)
+`,
+			ExitCode: ExitIssues,
+		},
+		{
+			Name: "only passed files are reported",
+			Args: []string{"check", "--config", "{dir}/custom.hcl", "{dir}/main.tf"},
+			Files: map[string]string{
+				mainFilename: `resource "azurerm_resource_group" "example" {
+  name = "example"
+}
+`,
+				"other.tf": `resource "azurerm_resource_group" "other" {
+  name = "${var.name}"
+}
+`,
+			},
+			Output:   "",
+			ExitCode: ExitOK,
+		},
+		{
+			Name:     "unknown format",
+			Args:     []string{"check", "--format", "xml", "{dir}"},
+			Files:    map[string]string{},
+			Output:   "",
+			ExitCode: ExitError,
+		},
+		{
+			Name:     "unknown command",
+			Args:     []string{"lint", "{dir}"},
+			Files:    map[string]string{},
+			Output:   "",
+			ExitCode: ExitError,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, args := prepareRun(t, tc.Files, tc.Args)
+
+			var stdout, stderr bytes.Buffer
+			exitCode := Run("dev", args, &stdout, &stderr)
+
+			require.Equal(t, tc.ExitCode, exitCode, stderr.String())
+			require.Equal(t, tc.Output, stripDir(stdout.String(), dir))
+		})
+	}
+}
+
+func Test_RunFix(t *testing.T) {
+	t.Parallel()
+
+	dir, args := prepareRun(t, map[string]string{
+		mainFilename: `variable "name" {
+  type = "string"
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "${var.name}"
+  location = "westeurope"
+}
+`,
+	}, []string{"fix", "--config", "{dir}/custom.hcl", "{dir}"})
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run("dev", args, &stdout, &stderr)
+	require.Equal(t, ExitOK, exitCode, stderr.String())
+	require.Empty(t, stdout.String())
+
+	fixed, err := os.ReadFile(filepath.Join(dir, mainFilename))
+	require.NoError(t, err)
+	require.Equal(t, `variable "name" {
+  type = string
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = var.name
+  location = "westeurope"
+}
+`, string(fixed))
+}
+
+// prepareRun writes files into a temporary directory and replaces
+// "{dir}" in arguments with its path.
+func prepareRun(t *testing.T, files map[string]string, args []string) (string, []string) {
+	t.Helper()
+
+	if _, ok := files["custom.hcl"]; !ok {
+		files["custom.hcl"] = formattingConfig
+	}
+	dir := writeFiles(t, files)
+
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		result = append(result, strings.ReplaceAll(arg, "{dir}", dir))
+	}
+
+	return dir, result
+}
+
+func stripDir(output, dir string) string {
+	return strings.ReplaceAll(output, dir+string(filepath.Separator), "")
+}
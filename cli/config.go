@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	defaultConfigFilename = ".tflint.hcl"
+	pluginName            = "dodo"
+)
+
+// Config is the subset of TFLint config file used by the plugin:
+//
+//	config {
+//	  disabled_by_default = false
+//	}
+//
+//	plugin "dodo" {
+//	  preset = "strict"
+//	}
+//
+//	rule "dodo_line_length" {
+//	  enabled = true
+//	  max     = 100
+//	}
+type Config struct {
+	DisabledByDefault bool
+	PluginBody        hcl.Body
+	Rules             map[string]*tflint.RuleConfig
+	RuleBodies        map[string]hcl.Body
+}
+
+type configFile struct {
+	Config  *configBlock  `hcl:"config,block"`
+	Plugins []pluginBlock `hcl:"plugin,block"`
+	Rules   []ruleBlock   `hcl:"rule,block"`
+	Remain  hcl.Body      `hcl:",remain"`
+}
+
+type configBlock struct {
+	DisabledByDefault bool     `hcl:"disabled_by_default,optional"`
+	Remain            hcl.Body `hcl:",remain"`
+}
+
+type pluginBlock struct {
+	Name string   `hcl:"name,label"`
+	Body hcl.Body `hcl:",remain"`
+}
+
+type ruleBlock struct {
+	Name    string   `hcl:"name,label"`
+	Enabled bool     `hcl:"enabled"`
+	Body    hcl.Body `hcl:",remain"`
+}
+
+func emptyConfig() *Config {
+	return &Config{
+		PluginBody: hcl.EmptyBody(),
+		Rules:      map[string]*tflint.RuleConfig{},
+		RuleBodies: map[string]hcl.Body{},
+	}
+}
+
+// LoadConfig reads TFLint config file. Missing default config file
+// is not an error, the default configuration is used instead.
+func LoadConfig(filename string) (*Config, error) {
+	config := emptyConfig()
+
+	explicit := filename != ""
+	if !explicit {
+		filename = defaultConfigFilename
+	}
+	src, err := os.ReadFile(filename)
+	if os.IsNotExist(err) && !explicit {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, diags := hclparse.NewParser().ParseHCL(src, filename)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var cfg configFile
+	if diags := gohcl.DecodeBody(file.Body, nil, &cfg); diags.HasErrors() {
+		return nil, diags
+	}
+
+	if cfg.Config != nil {
+		config.DisabledByDefault = cfg.Config.DisabledByDefault
+	}
+	for _, plugin := range cfg.Plugins {
+		if plugin.Name == pluginName {
+			config.PluginBody = plugin.Body
+		}
+	}
+	for _, rule := range cfg.Rules {
+		config.Rules[rule.Name] = &tflint.RuleConfig{Name: rule.Name, Enabled: rule.Enabled}
+		config.RuleBodies[rule.Name] = rule.Body
+	}
+
+	return config, nil
+}
+
+func (c *Config) tflintConfig() *tflint.Config {
+	return &tflint.Config{
+		Rules:             c.Rules,
+		DisabledByDefault: c.DisabledByDefault,
+		Body:              c.PluginBody,
+	}
+}
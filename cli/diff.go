@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+// diffContextLines is the number of unchanged lines around changes.
+const diffContextLines = 3
+
+// formatDiff prints autofixes as a unified diff, which can be applied with
+// "git apply" or posted as suggested changes with "reviewdog -f=diff".
+// Issues without fixes are skipped.
+func formatDiff(w io.Writer, report *Report) error {
+	fixes := map[string][]rules.Fix{}
+	for _, issue := range report.Issues {
+		if issue.Fix != nil {
+			fixes[issue.Fix.Range.Filename] = append(fixes[issue.Fix.Range.Filename], *issue.Fix)
+		}
+	}
+
+	filenames := make([]string, 0, len(fixes))
+	for filename := range fixes {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		src, ok := report.Sources[filename]
+		if !ok {
+			continue
+		}
+
+		diff, err := unifiedDiff(outputPath(filename), src, fixes[filename])
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lineChange replaces lines [from, to) of the source with lines.
+type lineChange struct {
+	from  int
+	to    int
+	fixes []rules.Fix
+	lines []string
+}
+
+func unifiedDiff(path string, src []byte, fixes []rules.Fix) (string, error) {
+	lines := splitLines(string(src))
+	starts := make([]int, len(lines)+1)
+	for i, line := range lines {
+		starts[i+1] = starts[i] + len(line)
+	}
+
+	changes, err := lineChanges(src, starts, fixes)
+	if err != nil || len(changes) == 0 {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+
+	var delta int
+	for i := 0; i < len(changes); {
+		// Changes separated by less than two contexts go to the same hunk.
+		j := i + 1
+		for j < len(changes) && changes[j].from-changes[j-1].to <= 2*diffContextLines {
+			j++
+		}
+
+		from := maxInt(changes[i].from-diffContextLines, 0)
+		to := minInt(changes[j-1].to+diffContextLines, len(lines))
+
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		pos := from
+		for _, change := range changes[i:j] {
+			for ; pos < change.from; pos++ {
+				writeDiffLine(&body, ' ', lines[pos])
+			}
+			for ; pos < change.to; pos++ {
+				writeDiffLine(&body, '-', lines[pos])
+			}
+			for _, line := range change.lines {
+				writeDiffLine(&body, '+', line)
+			}
+			oldCount += change.to - change.from
+			newCount += len(change.lines)
+		}
+		for ; pos < to; pos++ {
+			writeDiffLine(&body, ' ', lines[pos])
+		}
+		context := (to - from) - oldCount
+		oldCount += context
+		newCount += context
+
+		fmt.Fprintf(
+			&b,
+			"@@ -%s +%s @@\n%s",
+			hunkRange(from, oldCount),
+			hunkRange(from+delta, newCount),
+			body.String(),
+		)
+		delta += newCount - oldCount
+		i = j
+	}
+
+	return b.String(), nil
+}
+
+// lineChanges groups fixes touching the same lines and applies them.
+func lineChanges(src []byte, starts []int, fixes []rules.Fix) ([]*lineChange, error) {
+	sorted := make([]rules.Fix, len(fixes))
+	copy(sorted, fixes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte < sorted[j].Range.Start.Byte
+	})
+
+	changes := []*lineChange{}
+	for _, fix := range sorted {
+		from := fix.Range.Start.Line - 1
+		to := minInt(fix.Range.End.Line, len(starts)-1)
+		if from < 0 || from > to {
+			return nil, fmt.Errorf("fix range %s is out of file bounds", fix.Range)
+		}
+		// Range ending at the start of a line does not touch it.
+		if to-1 > from && fix.Range.End.Byte == starts[to-1] {
+			to--
+		}
+
+		if len(changes) != 0 && from < changes[len(changes)-1].to {
+			last := changes[len(changes)-1]
+			last.to = maxInt(last.to, to)
+			last.fixes = append(last.fixes, fix)
+			continue
+		}
+		changes = append(changes, &lineChange{from: from, to: to, fixes: []rules.Fix{fix}})
+	}
+
+	result := []*lineChange{}
+	for _, change := range changes {
+		offset := starts[change.from]
+		old := src[offset:starts[change.to]]
+
+		shifted := make([]rules.Fix, 0, len(change.fixes))
+		for _, fix := range change.fixes {
+			fix.Range.Start.Byte -= offset
+			fix.Range.End.Byte -= offset
+			shifted = append(shifted, fix)
+		}
+
+		fixed, err := rules.ApplyFixes(old, shifted)
+		if err != nil {
+			return nil, err
+		}
+		if string(fixed) == string(old) {
+			continue
+		}
+
+		change.lines = splitLines(string(fixed))
+		result = append(result, change)
+	}
+
+	return result, nil
+}
+
+// splitLines splits the text into lines keeping line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func writeDiffLine(b *strings.Builder, prefix byte, line string) {
+	b.WriteByte(prefix)
+	b.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// hunkRange formats the range of hunk lines, empty ranges
+// point to the line before them.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
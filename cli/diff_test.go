@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+// fixAt returns a fix replacing the text at the given line and column range.
+func fixAt(src string, line, startColumn, endColumn int, replacement string) rules.Fix {
+	offset := 0
+	for i := 1; i < line; i++ {
+		offset += strings.Index(src[offset:], "\n") + 1
+	}
+
+	return rules.Fix{
+		Range: hcl.Range{
+			Filename: mainFilename,
+			Start:    hcl.Pos{Line: line, Column: startColumn, Byte: offset + startColumn - 1},
+			End:      hcl.Pos{Line: line, Column: endColumn, Byte: offset + endColumn - 1},
+		},
+		Replacement: replacement,
+	}
+}
+
+func Test_UnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	src := "a = 1\nb = 2\nc = 3\nd = 4\ne = 5\nf = 6\ng = 7\nh = 8\ni = 9\nj = 10\nk = 11\nl = 12"
+
+	cases := []struct {
+		Name     string
+		Fixes    []rules.Fix
+		Expected string
+	}{
+		{
+			Name:     "no changes",
+			Fixes:    []rules.Fix{fixAt(src, 1, 5, 6, "1")},
+			Expected: "",
+		},
+		{
+			Name: "separate hunks",
+			Fixes: []rules.Fix{
+				fixAt(src, 12, 5, 7, "twelve"),
+				fixAt(src, 1, 5, 6, "one"),
+			},
+			Expected: `--- a/main.tf
++++ b/main.tf
+@@ -1,4 +1,4 @@
+-a = 1
++a = one
+ b = 2
+ c = 3
+ d = 4
+@@ -9,4 +9,4 @@
+ i = 9
+ j = 10
+ k = 11
+-l = 12
+\ No newline at end of file
++l = twelve
+\ No newline at end of file
+`,
+		},
+		{
+			Name: "merged hunk with multiline replacement",
+			Fixes: []rules.Fix{
+				fixAt(src, 2, 5, 6, "[\n  2,\n]"),
+				fixAt(src, 7, 1, 2, "gg"),
+			},
+			Expected: `--- a/main.tf
++++ b/main.tf
+@@ -1,10 +1,12 @@
+ a = 1
+-b = 2
++b = [
++  2,
++]
+ c = 3
+ d = 4
+ e = 5
+ f = 6
+-g = 7
++gg = 7
+ h = 8
+ i = 9
+ j = 10
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			diff, err := unifiedDiff(mainFilename, []byte(src), tc.Fixes)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, diff)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Output formats.
+const (
+	FormatText       = "text"
+	FormatJSON       = "json"
+	FormatCheckstyle = "checkstyle"
+	FormatSARIF      = "sarif"
+	FormatGitHub     = "github"
+	FormatDiff       = "diff"
+)
+
+// Report is a result of the check passed to formatters.
+type Report struct {
+	Version string
+	// Rules are enabled rules in order of their registration.
+	Rules  []tflint.Rule
+	Issues []*Issue
+	// Sources are contents of checked files used to render fixes.
+	Sources map[string][]byte
+}
+
+type formatter func(w io.Writer, report *Report) error
+
+var formatters = map[string]formatter{
+	FormatText:       formatText,
+	FormatJSON:       formatJSON,
+	FormatCheckstyle: formatCheckstyle,
+	FormatSARIF:      formatSARIF,
+	FormatGitHub:     formatGitHub,
+	FormatDiff:       formatDiff,
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// sortIssues orders issues by position, so output does not depend
+// on the order of rules and files.
+func sortIssues(issues []*Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Range, issues[j].Range
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Start.Line != b.Start.Line {
+			return a.Start.Line < b.Start.Line
+		}
+		if a.Start.Column != b.Start.Column {
+			return a.Start.Column < b.Start.Column
+		}
+
+		return issues[i].Rule.Name() < issues[j].Rule.Name()
+	})
+}
+
+// severityName returns lower case severity like "error" or "warning".
+func severityName(rule tflint.Rule) string {
+	return strings.ToLower(rule.Severity())
+}
+
+func formatText(w io.Writer, report *Report) error {
+	for _, issue := range report.Issues {
+		if _, err := fmt.Fprintf(
+			w,
+			"%s:%d:%d: %s: %s (%s)\n",
+			issue.Range.Filename,
+			issue.Range.Start.Line,
+			issue.Range.Start.Column,
+			severityName(issue.Rule),
+			issue.Message,
+			issue.Rule.Name(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type jsonPos struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type jsonRange struct {
+	Filename string  `json:"filename"`
+	Start    jsonPos `json:"start"`
+	End      jsonPos `json:"end"`
+}
+
+type jsonRule struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Link     string `json:"link"`
+}
+
+type jsonFix struct {
+	Range       jsonRange `json:"range"`
+	Replacement string    `json:"replacement"`
+}
+
+type jsonIssue struct {
+	Rule    jsonRule  `json:"rule"`
+	Message string    `json:"message"`
+	Range   jsonRange `json:"range"`
+	Fix     *jsonFix  `json:"fix,omitempty"`
+}
+
+type jsonOutput struct {
+	Issues []jsonIssue `json:"issues"`
+}
+
+func formatJSON(w io.Writer, report *Report) error {
+	output := jsonOutput{Issues: make([]jsonIssue, 0, len(report.Issues))}
+	for _, issue := range report.Issues {
+		item := jsonIssue{
+			Rule: jsonRule{
+				Name:     issue.Rule.Name(),
+				Severity: severityName(issue.Rule),
+				Link:     issue.Rule.Link(),
+			},
+			Message: issue.Message,
+			Range: jsonRange{
+				Filename: issue.Range.Filename,
+				Start:    jsonPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
+				End:      jsonPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
+			},
+		}
+		if issue.Fix != nil {
+			item.Fix = &jsonFix{
+				Range: jsonRange{
+					Filename: issue.Fix.Range.Filename,
+					Start:    jsonPos{Line: issue.Fix.Range.Start.Line, Column: issue.Fix.Range.Start.Column},
+					End:      jsonPos{Line: issue.Fix.Range.End.Line, Column: issue.Fix.Range.End.Column},
+				},
+				Replacement: issue.Fix.Replacement,
+			}
+		}
+		output.Issues = append(output.Issues, item)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(output)
+}
+
+type checkstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Source   string `xml:"source,attr"`
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Link     string `xml:"link,attr,omitempty"`
+}
+
+func formatCheckstyle(w io.Writer, report *Report) error {
+	output := checkstyleOutput{Version: "5.0"}
+	for _, issue := range report.Issues {
+		if len(output.Files) == 0 || output.Files[len(output.Files)-1].Name != issue.Range.Filename {
+			output.Files = append(output.Files, checkstyleFile{Name: issue.Range.Filename})
+		}
+
+		file := &output.Files[len(output.Files)-1]
+		file.Errors = append(file.Errors, checkstyleError{
+			Source:   issue.Rule.Name(),
+			Line:     issue.Range.Start.Line,
+			Column:   issue.Range.Start.Column,
+			Severity: checkstyleSeverity(issue.Rule),
+			Message:  issue.Message,
+			Link:     issue.Rule.Link(),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func checkstyleSeverity(rule tflint.Rule) string {
+	switch rule.Severity() {
+	case tflint.ERROR:
+		return "error"
+	case tflint.WARNING:
+		return "warning"
+	default:
+		return "info"
+	}
+}
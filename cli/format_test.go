@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+func testReport() *Report {
+	ruleSet := rules.NewRuleSet("0.2.0")
+	rule := ruleSet.Rules[0]
+
+	issues := []*Issue{
+		{
+			Rule:    rule,
+			Message: "Backend type should be \"azurerm\"",
+			Range: hcl.Range{
+				Filename: mainFilename,
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 14},
+				End:      hcl.Pos{Line: 2, Column: 15, Byte: 26},
+			},
+			Fix: &rules.Fix{
+				Range: hcl.Range{
+					Filename: mainFilename,
+					Start:    hcl.Pos{Line: 2, Column: 11, Byte: 22},
+					End:      hcl.Pos{Line: 2, Column: 15, Byte: 26},
+				},
+				Replacement: `"azurerm"`,
+			},
+		},
+	}
+
+	return &Report{
+		Version: ruleSet.Version,
+		Rules:   []tflint.Rule{rule},
+		Issues:  issues,
+		Sources: map[string][]byte{
+			mainFilename: []byte("terraform {\n  backend \"s3\" {}\n}\n"),
+		},
+	}
+}
+
+func Test_Formats(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Format   string
+		Expected string
+	}{
+		{
+			Name:   "text",
+			Format: FormatText,
+			Expected: `main.tf:2:3: error: Backend type should be "azurerm" (dodo_backend_type)
+`,
+		},
+		{
+			Name:   "json",
+			Format: FormatJSON,
+			Expected: `{
+  "issues": [
+    {
+      "rule": {
+        "name": "dodo_backend_type",
+        "severity": "error",
+        "link": "https://github.com/dodopizza/tflint-ruleset-dodo/blob/v0.2.0/docs/rules/dodo_backend_type.md"
+      },
+      "message": "Backend type should be \"azurerm\"",
+      "range": {
+        "filename": "main.tf",
+        "start": {
+          "line": 2,
+          "column": 3
+        },
+        "end": {
+          "line": 2,
+          "column": 15
+        }
+      },
+      "fix": {
+        "range": {
+          "filename": "main.tf",
+          "start": {
+            "line": 2,
+            "column": 11
+          },
+          "end": {
+            "line": 2,
+            "column": 15
+          }
+        },
+        "replacement": "\"azurerm\""
+      }
+    }
+  ]
+}
+`,
+		},
+		{
+			Name:   "checkstyle",
+			Format: FormatCheckstyle,
+			Expected: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="main.tf">
+    <error source="dodo_backend_type" line="2" column="3" severity="error" message="Backend type should be &#34;azurerm&#34;" link="https://github.com/dodopizza/tflint-ruleset-dodo/blob/v0.2.0/docs/rules/dodo_backend_type.md"></error>
+  </file>
+</checkstyle>
+`,
+		},
+		{
+			Name:   "sarif",
+			Format: FormatSARIF,
+			Expected: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "tflint-ruleset-dodo",
+          "version": "0.2.0",
+          "informationUri": "https://github.com/dodopizza/tflint-ruleset-dodo",
+          "rules": [
+            {
+              "id": "dodo_backend_type",
+              "shortDescription": {
+                "text": "Check that modules specify ` + "`azurerm`" + ` as backend type"
+              },
+              "helpUri": "https://github.com/dodopizza/tflint-ruleset-dodo/blob/v0.2.0/docs/rules/dodo_backend_type.md",
+              "defaultConfiguration": {
+                "level": "error"
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "dodo_backend_type",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {
+            "text": "Backend type should be \"azurerm\""
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "main.tf"
+                },
+                "region": {
+                  "startLine": 2,
+                  "startColumn": 3,
+                  "endLine": 2,
+                  "endColumn": 15
+                }
+              }
+            }
+          ],
+          "fixes": [
+            {
+              "description": {
+                "text": "Backend type should be \"azurerm\""
+              },
+              "artifactChanges": [
+                {
+                  "artifactLocation": {
+                    "uri": "main.tf"
+                  },
+                  "replacements": [
+                    {
+                      "deletedRegion": {
+                        "startLine": 2,
+                        "startColumn": 11,
+                        "endLine": 2,
+                        "endColumn": 15
+                      },
+                      "insertedContent": {
+                        "text": "\"azurerm\""
+                      }
+                    }
+                  ]
+                }
+              ]
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			Name:   "github",
+			Format: FormatGitHub,
+			Expected: `::error file=main.tf,line=2,col=3,endLine=2,endColumn=15,title=dodo_backend_type::Backend type should be "azurerm"%0Ahttps://github.com/dodopizza/tflint-ruleset-dodo/blob/v0.2.0/docs/rules/dodo_backend_type.md
+`,
+		},
+		{
+			Name:   "diff",
+			Format: FormatDiff,
+			Expected: `--- a/main.tf
++++ b/main.tf
+@@ -1,3 +1,3 @@
+ terraform {
+-  backend "s3" {}
++  backend "azurerm" {}
+ }
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			require.NoError(t, formatters[tc.Format](&out, testReport()))
+			require.Equal(t, tc.Expected, out.String())
+		})
+	}
+}
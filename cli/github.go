@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// formatGitHub prints GitHub Actions workflow commands creating annotations:
+//
+//	::error file=main.tf,line=1,col=1,endLine=1,endColumn=5,title=dodo_rule::Message
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func formatGitHub(w io.Writer, report *Report) error {
+	for _, issue := range report.Issues {
+		if _, err := fmt.Fprintf(
+			w,
+			"::%s file=%s,line=%d,col=%d,endLine=%d,endColumn=%d,title=%s::%s\n",
+			githubCommand(issue.Rule),
+			escapeGitHubProperty(issue.Range.Filename),
+			issue.Range.Start.Line,
+			issue.Range.Start.Column,
+			issue.Range.End.Line,
+			issue.Range.End.Column,
+			escapeGitHubProperty(issue.Rule.Name()),
+			escapeGitHubData(fmt.Sprintf("%s\n%s", issue.Message, issue.Rule.Link())),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func githubCommand(rule tflint.Rule) string {
+	switch rule.Severity() {
+	case tflint.ERROR:
+		return "error"
+	case tflint.WARNING:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var githubDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+var githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+func escapeGitHubData(s string) string {
+	return githubDataEscaper.Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return githubPropertyEscaper.Replace(s)
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// Module is a directory with Terraform files checked together.
+type Module struct {
+	Dir   string
+	Files map[string]*hcl.File
+
+	// only limits reported issues to the files passed explicitly,
+	// nil means all files of the module.
+	only map[string]bool
+}
+
+// Reports tells whether issues in the file should be reported.
+func (m *Module) Reports(filename string) bool {
+	return m.only == nil || m.only[filename]
+}
+
+// LoadModules finds Terraform files by paths. Directories are walked
+// recursively and every directory with Terraform files is a module. Files are
+// checked in the context of their module, but only their issues are reported.
+func LoadModules(paths []string) ([]*Module, error) {
+	modules := map[string]*Module{}
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			dir := filepath.Dir(path)
+			module, ok := modules[dir]
+			if !ok {
+				module = &Module{Dir: dir, only: map[string]bool{}}
+				modules[dir] = module
+			}
+			if module.only != nil {
+				module.only[path] = true
+			}
+			continue
+		}
+
+		if err := filepath.WalkDir(path, func(dir string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.IsDir() {
+				return err
+			}
+			if dir != path && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			// Whole directory overrides files passed explicitly.
+			modules[dir] = &Module{Dir: dir}
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	dirs := make([]string, 0, len(modules))
+	for dir := range modules {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	result := []*Module{}
+	for _, dir := range dirs {
+		module := modules[dir]
+		if err := module.load(); err != nil {
+			return nil, err
+		}
+		if len(module.Files) != 0 {
+			result = append(result, module)
+		}
+	}
+
+	return result, nil
+}
+
+// load parses all Terraform files of the module directory.
+func (m *Module) load() error {
+	entries, err := os.ReadDir(m.Dir)
+	if err != nil {
+		return err
+	}
+
+	parser := hclparse.NewParser()
+	m.Files = map[string]*hcl.File{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isTerraformFile(entry.Name()) {
+			continue
+		}
+
+		filename := filepath.Join(m.Dir, entry.Name())
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		if strings.HasSuffix(filename, ".json") {
+			file, diags = parser.ParseJSON(src, filename)
+		} else {
+			file, diags = parser.ParseHCL(src, filename)
+		}
+		if diags.HasErrors() {
+			return diags
+		}
+		m.Files[filename] = file
+	}
+
+	return nil
+}
+
+func isTerraformFile(name string) bool {
+	return strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")
+}
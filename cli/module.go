@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+// configFileSchema is the schema for the top-level of a config file.
+var configFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "provider", LabelNames: []string{"name"}},
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+		{Type: "output", LabelNames: []string{"name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+	},
+}
+
+var terraformBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "required_version"},
+		{Name: "experiments"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "backend", LabelNames: []string{"type"}},
+		{Type: "required_providers"},
+		{Type: "provider_meta", LabelNames: []string{"provider"}},
+	},
+}
+
+var resourceBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "count"},
+		{Name: "for_each"},
+		{Name: "provider"},
+		{Name: "depends_on"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "lifecycle"},
+	},
+}
+
+var lifecycleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "create_before_destroy"},
+		{Name: "prevent_destroy"},
+		{Name: "ignore_changes"},
+	},
+}
+
+// decodeModule decodes the parts of Terraform configuration used by rules.
+// Like the SDK test runner, it works without Terraform, so expressions are
+// not evaluated except for literal values.
+func decodeModule(dir string, files map[string]*hcl.File) (*configs.Config, hcl.Diagnostics) {
+	module := &configs.Module{
+		SourceDir:        dir,
+		ProviderConfigs:  map[string]*configs.Provider{},
+		Variables:        map[string]*configs.Variable{},
+		ModuleCalls:      map[string]*configs.ModuleCall{},
+		ManagedResources: map[string]*configs.Resource{},
+		DataResources:    map[string]*configs.Resource{},
+	}
+
+	var diags hcl.Diagnostics
+	for _, filename := range rules.SortedFilenames(files) {
+		content, _, contentDiags := files[filename].Body.PartialContent(configFileSchema)
+		diags = append(diags, contentDiags...)
+		if contentDiags.HasErrors() {
+			continue
+		}
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "terraform":
+				diags = append(diags, decodeTerraformBlock(module, block)...)
+			case "provider":
+				provider, providerDiags := decodeProviderBlock(block)
+				diags = append(diags, providerDiags...)
+				if provider != nil {
+					module.ProviderConfigs[rules.ProviderAddr(provider)] = provider
+				}
+			case "variable":
+				variable := decodeVariableBlock(block)
+				module.Variables[variable.Name] = variable
+			case "module":
+				call, callDiags := decodeModuleCallBlock(block)
+				diags = append(diags, callDiags...)
+				if call != nil {
+					module.ModuleCalls[call.Name] = call
+				}
+			case "resource":
+				resource, resourceDiags := decodeResourceBlock(block, addrs.ManagedResourceMode)
+				diags = append(diags, resourceDiags...)
+				if resource != nil {
+					module.ManagedResources[fmt.Sprintf("%s.%s", resource.Type, resource.Name)] = resource
+				}
+			case "data":
+				resource, resourceDiags := decodeResourceBlock(block, addrs.DataResourceMode)
+				diags = append(diags, resourceDiags...)
+				if resource != nil {
+					module.DataResources[fmt.Sprintf("data.%s.%s", resource.Type, resource.Name)] = resource
+				}
+			}
+		}
+	}
+
+	return &configs.Config{Module: module}, diags
+}
+
+func decodeTerraformBlock(module *configs.Module, block *hcl.Block) hcl.Diagnostics {
+	content, _, diags := block.Body.PartialContent(terraformBlockSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for _, block := range content.Blocks {
+		if block.Type == "backend" {
+			module.Backend = &configs.Backend{
+				Type:      block.Labels[0],
+				TypeRange: block.LabelRanges[0],
+				Config:    block.Body,
+				DeclRange: block.DefRange,
+			}
+		}
+	}
+
+	return diags
+}
+
+func decodeProviderBlock(block *hcl.Block) (*configs.Provider, hcl.Diagnostics) {
+	content, config, diags := block.Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "alias"},
+			{Name: "version"},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	provider := &configs.Provider{
+		Name:      block.Labels[0],
+		NameRange: block.LabelRanges[0],
+		Config:    config,
+		DeclRange: block.DefRange,
+	}
+	if attr, ok := content.Attributes["alias"]; ok {
+		diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &provider.Alias)...)
+		provider.AliasRange = attr.Expr.Range().Ptr()
+	}
+
+	return provider, diags
+}
+
+// decodeVariableBlock decodes only the default value, type constraints
+// require Terraform type expressions which are not needed by rules.
+func decodeVariableBlock(block *hcl.Block) *configs.Variable {
+	variable := &configs.Variable{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	content, _, diags := block.Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "default"},
+		},
+	})
+	if diags.HasErrors() {
+		return variable
+	}
+
+	if attr, ok := content.Attributes["default"]; ok {
+		if val, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+			variable.Default = val
+		}
+	}
+
+	return variable
+}
+
+func decodeModuleCallBlock(block *hcl.Block) (*configs.ModuleCall, hcl.Diagnostics) {
+	content, remain, diags := block.Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "source", Required: true},
+			{Name: "version"},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	call := &configs.ModuleCall{
+		Name:      block.Labels[0],
+		Config:    remain,
+		DeclRange: block.DefRange,
+	}
+
+	if attr, ok := content.Attributes["source"]; ok {
+		if diags := gohcl.DecodeExpression(attr.Expr, nil, &call.SourceAddr); diags.HasErrors() {
+			return nil, diags
+		}
+		call.SourceAddrRange = attr.Expr.Range()
+		call.SourceSet = true
+	}
+
+	if attr, ok := content.Attributes["version"]; ok {
+		var value string
+		if diags := gohcl.DecodeExpression(attr.Expr, nil, &value); diags.HasErrors() {
+			return nil, diags
+		}
+
+		required, err := version.NewConstraint(value)
+		if err != nil {
+			return nil, hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid version constraint",
+					Detail:   err.Error(),
+					Subject:  attr.Expr.Range().Ptr(),
+				},
+			}
+		}
+		call.Version = configs.VersionConstraint{
+			Required:  required,
+			DeclRange: attr.Expr.Range(),
+		}
+	}
+
+	return call, diags
+}
+
+func decodeResourceBlock(block *hcl.Block, mode addrs.ResourceMode) (*configs.Resource, hcl.Diagnostics) {
+	content, remain, diags := block.Body.PartialContent(resourceBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	resource := &configs.Resource{
+		Mode:      mode,
+		Type:      block.Labels[0],
+		Name:      block.Labels[1],
+		Config:    remain,
+		DeclRange: block.DefRange,
+		TypeRange: block.LabelRanges[0],
+	}
+	if attr, ok := content.Attributes["count"]; ok {
+		resource.Count = attr.Expr
+	}
+	if attr, ok := content.Attributes["for_each"]; ok {
+		resource.ForEach = attr.Expr
+	}
+	if mode != addrs.ManagedResourceMode {
+		return resource, diags
+	}
+
+	resource.Managed = &configs.ManagedResource{}
+	for _, block := range content.Blocks {
+		lifecycle, _, lifecycleDiags := block.Body.PartialContent(lifecycleBlockSchema)
+		diags = append(diags, lifecycleDiags...)
+		if lifecycleDiags.HasErrors() {
+			continue
+		}
+
+		if attr, ok := lifecycle.Attributes["create_before_destroy"]; ok {
+			diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &resource.Managed.CreateBeforeDestroy)...)
+			resource.Managed.CreateBeforeDestroySet = true
+		}
+		if attr, ok := lifecycle.Attributes["prevent_destroy"]; ok {
+			diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &resource.Managed.PreventDestroy)...)
+			resource.Managed.PreventDestroySet = true
+		}
+		if attr, ok := lifecycle.Attributes["ignore_changes"]; ok {
+			resource.Managed.IgnoreAllChanges = hcl.ExprAsKeyword(attr.Expr) == "all"
+		}
+	}
+
+	return resource, diags
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_DecodeModule(t *testing.T) {
+	t.Parallel()
+
+	file, diags := hclparse.NewParser().ParseHCL([]byte(`
+terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {
+  alias = "shared"
+
+  features {}
+}
+
+variable "name" {
+  type    = map(string)
+  default = { key = "value" }
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "Name should not be empty."
+  }
+}
+
+module "network" {
+  source  = "dodopizza/network/azurerm"
+  version = "~> 1.0"
+}
+
+resource "azurerm_storage_account" "example" {
+  count = 1
+
+  lifecycle {
+    prevent_destroy = true
+    ignore_changes  = all
+  }
+}
+
+data "azurerm_client_config" "current" {}
+`), mainFilename)
+	require.False(t, diags.HasErrors(), diags)
+
+	config, diags := decodeModule(".", map[string]*hcl.File{mainFilename: file})
+	require.False(t, diags.HasErrors(), diags)
+
+	module := config.Module
+	require.Equal(t, "azurerm", module.Backend.Type)
+	require.Equal(t, "shared", module.ProviderConfigs["azurerm.shared"].Alias)
+	require.Equal(t, cty.ObjectVal(map[string]cty.Value{"key": cty.StringVal("value")}), module.Variables["name"].Default)
+	require.Equal(t, "dodopizza/network/azurerm", module.ModuleCalls["network"].SourceAddr)
+	require.Equal(t, "~> 1.0", module.ModuleCalls["network"].Version.Required.String())
+
+	resource := module.ManagedResources["azurerm_storage_account.example"]
+	require.NotNil(t, resource.Count)
+	require.True(t, resource.Managed.PreventDestroy)
+	require.True(t, resource.Managed.IgnoreAllChanges)
+	require.Contains(t, module.DataResources, "data.azurerm_client_config.current")
+}
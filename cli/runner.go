@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+// Issue is a problem found by a rule along with its autofix, if any.
+type Issue struct {
+	Rule    tflint.Rule
+	Message string
+	Range   hcl.Range
+	Fix     *rules.Fix
+	// FileScoped issues concern the whole file rather than their range.
+	FileScoped bool
+}
+
+// Runner is an in-process implementation of tflint.Runner for a single module.
+type Runner struct {
+	files       map[string]*hcl.File
+	config      *configs.Config
+	ruleConfigs map[string]hcl.Body
+
+	Issues []*Issue
+}
+
+var (
+	_ tflint.Runner          = &Runner{}
+	_ rules.Fixer            = &Runner{}
+	_ rules.FileIssueEmitter = &Runner{}
+)
+
+// NewRunner decodes the module from its files. Rule options are taken
+// from remaining bodies of rule blocks keyed by rule names.
+func NewRunner(dir string, files map[string]*hcl.File, ruleConfigs map[string]hcl.Body) (*Runner, error) {
+	config, diags := decodeModule(dir, files)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Runner{
+		files:       files,
+		config:      config,
+		ruleConfigs: ruleConfigs,
+	}, nil
+}
+
+func (r *Runner) WalkResourceAttributes(resourceType, attributeName string, walker func(*hcl.Attribute) error) error {
+	return r.WalkResources(resourceType, func(resource *configs.Resource) error {
+		content, _, diags := resource.Config.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{{Name: attributeName}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		if attr, ok := content.Attributes[attributeName]; ok {
+			return walker(attr)
+		}
+
+		return nil
+	})
+}
+
+func (r *Runner) WalkResourceBlocks(resourceType, blockType string, walker func(*hcl.Block) error) error {
+	return r.WalkResources(resourceType, func(resource *configs.Resource) error {
+		content, _, diags := resource.Config.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: blockType}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			if err := walker(block); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Runner) WalkResources(resourceType string, walker func(*configs.Resource) error) error {
+	addrs := make([]string, 0, len(r.config.Module.ManagedResources))
+	for addr, resource := range r.config.Module.ManagedResources {
+		if resource.Type == resourceType {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+
+	for _, addr := range addrs {
+		if err := walker(r.config.Module.ManagedResources[addr]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) WalkModuleCalls(walker func(*configs.ModuleCall) error) error {
+	names := make([]string, 0, len(r.config.Module.ModuleCalls))
+	for name := range r.config.Module.ModuleCalls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := walker(r.config.Module.ModuleCalls[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) Backend() (*configs.Backend, error) {
+	return r.config.Module.Backend, nil
+}
+
+func (r *Runner) Config() (*configs.Config, error) {
+	return r.config, nil
+}
+
+func (r *Runner) File(filename string) (*hcl.File, error) {
+	return r.files[filename], nil
+}
+
+func (r *Runner) Files() (map[string]*hcl.File, error) {
+	return r.files, nil
+}
+
+// RootProvider returns the provider of the module itself,
+// the CLI checks every module as a root one.
+func (r *Runner) RootProvider(name string) (*configs.Provider, error) {
+	return r.config.Module.ProviderConfigs[name], nil
+}
+
+func (r *Runner) DecodeRuleConfig(name string, ret interface{}) error {
+	body, ok := r.ruleConfigs[name]
+	if !ok {
+		return nil
+	}
+	if diags := gohcl.DecodeBody(body, nil, ret); diags.HasErrors() {
+		return diags
+	}
+
+	return nil
+}
+
+// EvaluateExpr evaluates expressions referencing only variable defaults,
+// local values and the workspace. Like TFLint, it returns warnings for unevaluable expressions
+// and unknown or null values, which EnsureNoError skips.
+func (r *Runner) EvaluateExpr(expr hcl.Expression, ret interface{}, wantType *cty.Type) error {
+	ty := cty.DynamicPseudoType
+	if wantType != nil {
+		ty = *wantType
+	} else if impliedType, err := gocty.ImpliedType(ret); err == nil {
+		ty = impliedType
+	}
+
+	for _, traversal := range expr.Variables() {
+		if !isEvaluableRoot(traversal.RootName()) {
+			return tflint.Error{
+				Code:    tflint.UnevaluableError,
+				Level:   tflint.WarningLevel,
+				Message: fmt.Sprintf("Unevaluable expression found in %s", expr.Range()),
+			}
+		}
+	}
+
+	val, diags := expr.Value(rules.EvalContext(r.config.Module))
+	if diags.HasErrors() {
+		return tflint.Error{
+			Code:    tflint.EvaluationError,
+			Level:   tflint.ErrorLevel,
+			Message: fmt.Sprintf("Failed to eval an expression in %s", expr.Range()),
+			Cause:   diags,
+		}
+	}
+	if !val.IsWhollyKnown() {
+		return tflint.Error{
+			Code:    tflint.UnknownValueError,
+			Level:   tflint.WarningLevel,
+			Message: fmt.Sprintf("Unknown value found in %s", expr.Range()),
+		}
+	}
+	if val.IsNull() {
+		return tflint.Error{
+			Code:    tflint.NullValueError,
+			Level:   tflint.WarningLevel,
+			Message: fmt.Sprintf("Null value found in %s", expr.Range()),
+		}
+	}
+
+	val, err := convert.Convert(val, ty)
+	if err != nil {
+		return tflint.Error{
+			Code:    tflint.TypeConversionError,
+			Level:   tflint.ErrorLevel,
+			Message: fmt.Sprintf("Invalid type expression in %s", expr.Range()),
+			Cause:   err,
+		}
+	}
+
+	return gocty.FromCtyValue(val, ret)
+}
+
+// isEvaluableRoot reports whether references with the root are evaluated.
+func isEvaluableRoot(name string) bool {
+	for _, root := range rules.EvaluableRoots {
+		if root == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Runner) EvaluateExprOnRootCtx(expr hcl.Expression, ret interface{}, wantType *cty.Type) error {
+	return r.EvaluateExpr(expr, ret, wantType)
+}
+
+func (r *Runner) IsNullExpr(expr hcl.Expression) (bool, error) {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return false, diags
+	}
+
+	return val.IsNull(), nil
+}
+
+func (r *Runner) EmitIssueOnExpr(rule tflint.Rule, message string, expr hcl.Expression) error {
+	return r.EmitIssue(rule, message, expr.Range())
+}
+
+func (r *Runner) EmitIssue(rule tflint.Rule, message string, location hcl.Range) error {
+	r.Issues = append(r.Issues, &Issue{
+		Rule:    rule,
+		Message: message,
+		Range:   location,
+	})
+
+	return nil
+}
+
+func (r *Runner) EmitIssueWithFix(rule tflint.Rule, message string, location hcl.Range, fix rules.Fix) error {
+	r.Issues = append(r.Issues, &Issue{
+		Rule:    rule,
+		Message: message,
+		Range:   location,
+		Fix:     &fix,
+	})
+
+	return nil
+}
+
+func (r *Runner) EmitFileIssue(rule tflint.Rule, message string, location hcl.Range) error {
+	r.Issues = append(r.Issues, &Issue{
+		Rule:       rule,
+		Message:    message,
+		Range:      location,
+		FileScoped: true,
+	})
+
+	return nil
+}
+
+// EnsureNoError skips proc on warnings like TFLint does, so values which
+// cannot be evaluated are ignored.
+func (r *Runner) EnsureNoError(err error, proc func() error) error {
+	if err == nil {
+		return proc()
+	}
+
+	var appErr tflint.Error
+	if errors.As(err, &appErr) && appErr.Level == tflint.WarningLevel {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "tflint-ruleset-dodo"
+	toolURI      = "https://github.com/dodopizza/tflint-ruleset-dodo"
+)
+
+// SARIF 2.1.0 log, only the properties used by code scanning services.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+func formatSARIF(w io.Writer, report *Report) error {
+	driver := sarifDriver{
+		Name:           toolName,
+		Version:        report.Version,
+		InformationURI: toolURI,
+		Rules:          make([]sarifRule, 0, len(report.Rules)),
+	}
+	ruleIndexes := map[string]int{}
+	addRule := func(rule tflint.Rule) int {
+		if index, ok := ruleIndexes[rule.Name()]; ok {
+			return index
+		}
+
+		var description string
+		if r, ok := rule.(*rules.Rule); ok {
+			description = r.Documentation().Description
+		}
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.Name(),
+			ShortDescription:     sarifMessage{Text: description},
+			HelpURI:              rule.Link(),
+			DefaultConfiguration: sarifRuleConfiguration{Level: sarifLevel(rule)},
+		})
+		ruleIndexes[rule.Name()] = len(driver.Rules) - 1
+
+		return len(driver.Rules) - 1
+	}
+	for _, rule := range report.Rules {
+		addRule(rule)
+	}
+
+	results := make([]sarifResult, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		result := sarifResult{
+			RuleID:    issue.Rule.Name(),
+			RuleIndex: addRule(issue.Rule),
+			Level:     sarifLevel(issue.Rule),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: outputPath(issue.Range.Filename)},
+						Region:           newSARIFRegion(issue.Range),
+					},
+				},
+			},
+		}
+		if issue.Fix != nil {
+			result.Fixes = []sarifFix{
+				{
+					Description: sarifMessage{Text: issue.Message},
+					ArtifactChanges: []sarifArtifactChange{
+						{
+							ArtifactLocation: sarifArtifactLocation{URI: outputPath(issue.Fix.Range.Filename)},
+							Replacements: []sarifReplacement{
+								{
+									DeletedRegion:   newSARIFRegion(issue.Fix.Range),
+									InsertedContent: sarifMessage{Text: issue.Fix.Replacement},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+		results = append(results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: driver},
+				Results: results,
+			},
+		},
+	})
+}
+
+func newSARIFRegion(r hcl.Range) sarifRegion {
+	return sarifRegion{
+		StartLine:   r.Start.Line,
+		StartColumn: r.Start.Column,
+		EndLine:     r.End.Line,
+		EndColumn:   r.End.Column,
+	}
+}
+
+// outputPath returns the file path with forward slashes,
+// which is a relative URI reference as well.
+func outputPath(filename string) string {
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+func sarifLevel(rule tflint.Rule) string {
+	switch rule.Severity() {
+	case tflint.ERROR:
+		return "error"
+	case tflint.WARNING:
+		return "warning"
+	default:
+		return "note"
+	}
+}
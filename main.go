@@ -1,26 +1,27 @@
+//go:generate go run ./tools/docgen
+
 package main
 
 import (
+	"os"
+
 	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
-	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 
+	"github.com/dodopizza/tflint-ruleset-dodo/cli"
 	"github.com/dodopizza/tflint-ruleset-dodo/rules"
 )
 
-var version = "0.1.0"
+// version is injected at build time with "-X main.version=<version>".
+var version = "dev"
 
 func main() {
+	// TFLint starts the plugin without arguments,
+	// so any command means the standalone CLI usage.
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(version, os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "dodo",
-			Version: version,
-			Rules: []tflint.Rule{
-				rules.NewBackendTypeRule(),
-				rules.NewFileContentRule(),
-				rules.NewCommentsRule(),
-				rules.NewForeachCountRule(),
-				rules.NewModuleStructureRule(),
-			},
-		},
+		RuleSet: rules.NewRuleSet(version),
 	})
 }
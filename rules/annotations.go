@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// annotationPrefix starts all annotations which opt out of dodo checks,
+// e.g. "// dodo:allow-destroy" placed in comments before the resource or at
+// the end of its first line.
+const annotationPrefix = "dodo:"
+
+// getAnnotatedLines returns lines per file of declarations annotated either
+// anywhere in the comment block preceding them or by a trailing comment on
+// the declaration line.
+func getAnnotatedLines(runner tflint.Runner, annotation string) (map[string]map[int]bool, error) {
+	files, err := runner.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	annotated := map[string]map[int]bool{}
+	for _, filename := range SortedFilenames(files) {
+		if strings.HasSuffix(filename, ".json") {
+			continue
+		}
+
+		tokens, err := lexFile(filename, files[filename])
+		if err != nil {
+			return nil, err
+		}
+
+		lines := map[int]bool{}
+		var pending bool
+		lastLine := 0
+		for _, token := range tokens {
+			switch token.Type {
+			case hclsyntax.TokenComment:
+				if !strings.Contains(string(token.Bytes), annotationPrefix+annotation) {
+					continue
+				}
+				if token.Range.Start.Line == lastLine {
+					lines[lastLine] = true
+				} else {
+					pending = true
+				}
+			case hclsyntax.TokenNewline:
+			default:
+				if pending {
+					lines[token.Range.Start.Line] = true
+				}
+				pending = false
+				lastLine = token.Range.End.Line
+			}
+		}
+		annotated[filename] = lines
+	}
+
+	return annotated, nil
+}
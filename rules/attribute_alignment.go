@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const attributeAlignmentMessage = "Equals sign should be aligned with other attributes in the group " +
+	"like terraform fmt does"
+
+func NewAttributeAlignmentRule() *Rule {
+	return NewRule(
+		"attribute_alignment",
+		Documentation{
+			Description: "Check that equals signs of consecutive attributes are aligned the same way as `terraform fmt` does",
+			Example: `resource "azurerm_resource_group" "example" {
+  name = "example"
+  location = "westeurope"
+}
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for filename, file := range files {
+				if err := checkAttributeAlignment(runner, rule, filename, file); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+// checkAttributeAlignment compares spaces before each assignment with the
+// output of hclwrite.Format. Formatting changes only whitespace, so tokens of
+// the original and formatted sources match one to one.
+func checkAttributeAlignment(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	filename string,
+	file *hcl.File,
+) error {
+	if strings.HasSuffix(filename, ".json") {
+		return nil
+	}
+
+	tokens, diags := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+	formatted, diags := hclsyntax.LexConfig(hclwrite.Format(file.Bytes), filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+	if len(tokens) != len(formatted) {
+		return nil
+	}
+
+	for _, line := range linesForFormat(tokens) {
+		if line.assign == -1 {
+			continue
+		}
+
+		lead, equal := tokens[line.assign-1], tokens[line.assign]
+		spaces := equal.Range.Start.Byte - lead.Range.End.Byte
+		want := formatted[line.assign].Range.Start.Byte - formatted[line.assign-1].Range.End.Byte
+		if spaces == want {
+			continue
+		}
+
+		if err := emitIssueWithFix(
+			runner,
+			rule,
+			attributeAlignmentMessage,
+			hcl.Range{
+				Filename: filename,
+				Start:    tokens[line.start].Range.Start,
+				End:      equal.Range.End,
+			},
+			Fix{
+				Range: hcl.Range{
+					Filename: filename,
+					Start:    lead.Range.End,
+					End:      equal.Range.Start,
+				},
+				Replacement: strings.Repeat(" ", want),
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatLine is a line of tokens split in the same way as hclwrite does it.
+// The start is an index of the first token of the line and the assign is an
+// index of the equals sign starting the assign cell, or -1 if there is none.
+type formatLine struct {
+	start  int
+	assign int
+}
+
+func linesForFormat(tokens hclsyntax.Tokens) []formatLine {
+	lines := []formatLine{}
+
+	start := 0
+	for i, token := range tokens {
+		if token.Type == hclsyntax.TokenEOF {
+			lines = append(lines, newFormatLine(tokens, start, i))
+			break
+		}
+		if isNewlineToken(token) {
+			lines = append(lines, newFormatLine(tokens, start, i+1))
+			start = i + 1
+		}
+	}
+
+	return lines
+}
+
+func newFormatLine(tokens hclsyntax.Tokens, start, end int) formatLine {
+	line := formatLine{start: start, assign: -1}
+
+	// Trailing comment forms its own cell.
+	if end-start > 1 && tokens[end-1].Type == hclsyntax.TokenComment {
+		end--
+	}
+
+	for i := start + 1; i < end; i++ {
+		if tokens[i].Type != hclsyntax.TokenEqual {
+			continue
+		}
+
+		// Multiline expressions do not take part in alignment.
+		var brackets int
+		for _, token := range tokens[i:end] {
+			brackets += bracketChange(token)
+		}
+		if brackets == 0 {
+			line.assign = i
+		}
+
+		break
+	}
+
+	return line
+}
+
+func isNewlineToken(token hclsyntax.Token) bool {
+	if token.Type == hclsyntax.TokenNewline {
+		return true
+	}
+
+	// Single line comments consume their terminating newline.
+	return token.Type == hclsyntax.TokenComment &&
+		strings.HasSuffix(string(token.Bytes), "\n")
+}
+
+func bracketChange(token hclsyntax.Token) int {
+	switch token.Type {
+	case hclsyntax.TokenOBrace,
+		hclsyntax.TokenOBrack,
+		hclsyntax.TokenOParen,
+		hclsyntax.TokenTemplateControl,
+		hclsyntax.TokenTemplateInterp:
+		return 1
+	case hclsyntax.TokenCBrace,
+		hclsyntax.TokenCBrack,
+		hclsyntax.TokenCParen,
+		hclsyntax.TokenTemplateSeqEnd:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,154 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AttributeAlignment(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "no issues",
+			Content: `resource "null_resource" "test" {
+  name        = "test"
+  description = "test"
+
+  key = "value"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no issues with multiline value breaking the group",
+			Content: `resource "null_resource" "test" {
+  name = "test"
+  tags = {
+    environment = "dev"
+    team        = "platform"
+  }
+  description = "test"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no issues with heredoc aligned as terraform fmt does",
+			Content: `resource "null_resource" "test" {
+  name        = <<EOT
+a = b
+EOT
+  description = "test"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "misaligned attribute",
+			Content: `resource "null_resource" "test" {
+  name = "test"
+  description = "test"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAttributeAlignmentRule(),
+					Message: attributeAlignmentMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 9},
+					},
+				},
+			},
+			Fixed: `resource "null_resource" "test" {
+  name        = "test"
+  description = "test"
+}
+`,
+		},
+		{
+			Name: "extra spaces in separate group",
+			Content: `resource "null_resource" "test" {
+  name        = "test"
+
+  description = "test"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAttributeAlignmentRule(),
+					Message: attributeAlignmentMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 16},
+					},
+				},
+			},
+			Fixed: `resource "null_resource" "test" {
+  name = "test"
+
+  description = "test"
+}
+`,
+		},
+		{
+			Name: "misaligned object keys",
+			Content: `locals {
+  tags = {
+    environment = "dev"
+    team    = "platform"
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAttributeAlignmentRule(),
+					Message: attributeAlignmentMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 4, Column: 5},
+						End:      hcl.Pos{Line: 4, Column: 14},
+					},
+				},
+			},
+			Fixed: `locals {
+  tags = {
+    environment = "dev"
+    team        = "platform"
+  }
+}
+`,
+		},
+	}
+	rule := NewAttributeAlignmentRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newFixRunner(t, map[string]string{filename: tc.Content})
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+
+			if tc.Fixed == "" {
+				return
+			}
+			fixed, err := ApplyFixes([]byte(tc.Content), runner.Fixes)
+			require.NoError(t, err)
+			require.Equal(t, tc.Fixed, string(fixed))
+		})
+	}
+}
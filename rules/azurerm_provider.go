@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const azurermProvider = "azurerm"
+
+const (
+	azurermFeaturesMissingMessageTemplate       = "Provider \"%s\" should have features block"
+	azurermSubscriptionIDMissingMessageTemplate = "Provider \"%s\" should set subscription_id from a variable"
+	azurermSubscriptionIDLiteralMessageTemplate = "Provider \"%s\" subscription_id should be set from a variable, " +
+		"not a literal"
+	azurermSkipProviderRegistrationMessageTemplate = "Provider \"%s\" should not set skip_provider_registration"
+)
+
+var azurermProviderSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "subscription_id"},
+		{Name: "skip_provider_registration"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "features"},
+	},
+}
+
+type azurermProviderRuleConfig struct {
+	SkipProviderRegistrationAllowlist []string `hcl:"skip_provider_registration_allowlist,optional"`
+}
+
+func NewAzurermProviderRule() *Rule {
+	return NewRule(
+		"azurerm_provider",
+		Documentation{
+			Description: "Check that `azurerm` provider in root modules has `features` block, takes " +
+				"`subscription_id` from a variable and does not set `skip_provider_registration` unless allowlisted",
+			Example: `provider "azurerm" {
+  subscription_id            = "00000000-0000-0000-0000-000000000000"
+  skip_provider_registration = true
+}
+`,
+			Config: `skip_provider_registration_allowlist = ["azurerm.legacy"]
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := azurermProviderRuleConfig{}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			root, err := isRootModule(runner)
+			if err != nil || !root {
+				return err
+			}
+
+			cfg, err := runner.Config()
+			if err != nil {
+				return err
+			}
+
+			addrs := make([]string, 0, len(cfg.Module.ProviderConfigs))
+			for addr, provider := range cfg.Module.ProviderConfigs {
+				if provider.Name == azurermProvider {
+					addrs = append(addrs, addr)
+				}
+			}
+			sort.Strings(addrs)
+
+			for _, addr := range addrs {
+				if err := checkAzurermProvider(
+					runner,
+					rule,
+					config,
+					cfg.Module.ProviderConfigs[addr],
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkAzurermProvider(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	config azurermProviderRuleConfig,
+	provider *configs.Provider,
+) error {
+	addr := ProviderAddr(provider)
+	content, _, diags := provider.Config.PartialContent(azurermProviderSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if len(content.Blocks) == 0 {
+		if err := runner.EmitIssue(
+			rule,
+			fmt.Sprintf(azurermFeaturesMissingMessageTemplate, addr),
+			provider.DeclRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	subscriptionID, ok := content.Attributes["subscription_id"]
+	switch {
+	case !ok:
+		if err := runner.EmitIssue(
+			rule,
+			fmt.Sprintf(azurermSubscriptionIDMissingMessageTemplate, addr),
+			provider.DeclRange,
+		); err != nil {
+			return err
+		}
+	case len(subscriptionID.Expr.Variables()) == 0:
+		if err := runner.EmitIssueOnExpr(
+			rule,
+			fmt.Sprintf(azurermSubscriptionIDLiteralMessageTemplate, addr),
+			subscriptionID.Expr,
+		); err != nil {
+			return err
+		}
+	}
+
+	if skip, ok := content.Attributes["skip_provider_registration"]; ok &&
+		!containsString(config.SkipProviderRegistrationAllowlist, addr) {
+		return runner.EmitIssue(
+			rule,
+			fmt.Sprintf(azurermSkipProviderRegistrationMessageTemplate, addr),
+			skip.Range,
+		)
+	}
+
+	return nil
+}
+
+// ProviderAddr returns a provider address in "name" or "name.alias" form,
+// the key of the provider in module provider configs.
+func ProviderAddr(provider *configs.Provider) string {
+	if provider.Alias == "" {
+		return provider.Name
+	}
+
+	return fmt.Sprintf("%s.%s", provider.Name, provider.Alias)
+}
@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermProvider(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {
+  subscription_id = var.subscription_id
+
+  features {}
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no issues in child module",
+			Content: map[string]string{
+				filename: `provider "azurerm" {}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no features and subscription_id",
+			Content: map[string]string{
+				filename: `terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderRule(),
+					Message: fmt.Sprintf(azurermFeaturesMissingMessageTemplate, "azurerm"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 19},
+					},
+				},
+				{
+					Rule:    NewAzurermProviderRule(),
+					Message: fmt.Sprintf(azurermSubscriptionIDMissingMessageTemplate, "azurerm"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "literal subscription_id and skip_provider_registration",
+			Content: map[string]string{
+				filename: `terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {
+  alias                      = "hub"
+  subscription_id            = "00000000-0000-0000-0000-000000000000"
+  skip_provider_registration = true
+
+  features {}
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderRule(),
+					Message: fmt.Sprintf(azurermSubscriptionIDLiteralMessageTemplate, "azurerm.hub"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 7, Column: 32},
+						End:      hcl.Pos{Line: 7, Column: 70},
+					},
+				},
+				{
+					Rule:    NewAzurermProviderRule(),
+					Message: fmt.Sprintf(azurermSkipProviderRegistrationMessageTemplate, "azurerm.hub"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 36},
+					},
+				},
+			},
+		},
+		{
+			Name: "allowlisted skip_provider_registration",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_azurerm_provider" {
+  enabled                              = true
+  skip_provider_registration_allowlist = ["azurerm"]
+}
+`,
+				filename: `terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {
+  subscription_id            = var.subscription_id
+  skip_provider_registration = true
+
+  features {}
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+	}
+	rule := NewAzurermProviderRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+			decodeProviderConfigs(t, runner)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
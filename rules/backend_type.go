@@ -14,6 +14,13 @@ const (
 func NewBackendTypeRule() *Rule {
 	return NewRule(
 		"backend_type",
+		Documentation{
+			Description: "Check that modules specify `azurerm` as backend type",
+			Example: `terraform {
+  backend "s3" {}
+}
+`,
+		},
 		func(runner tflint.Runner, rule tflint.Rule) error {
 			backend, err := runner.Backend()
 			if err != nil {
@@ -13,6 +13,14 @@ const commentsMessage = "Single line comments should begin with \"//\""
 func NewCommentsRule() *Rule {
 	return NewRule(
 		"comments",
+		Documentation{
+			Description: "Check that all comments written in consistent way",
+			Example: `# Resource group for the example.
+resource "azurerm_resource_group" "example" {
+  name = "example"
+}
+`,
+		},
 		func(runner tflint.Runner, rule tflint.Rule) error {
 			files, err := runner.Files()
 			if err != nil {
@@ -40,9 +48,9 @@ func checkComments(
 		return nil
 	}
 
-	tokens, diags := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
-	if diags.HasErrors() {
-		return diags
+	tokens, err := lexFile(filename, file)
+	if err != nil {
+		return err
 	}
 
 	for _, token := range tokens {
@@ -0,0 +1,273 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	deprecatedStatus = "deprecated"
+	forbiddenStatus  = "forbidden"
+)
+
+const (
+	deprecatedTypeMessageTemplate     = "%s type \"%s\" is %s"
+	deprecatedArgumentMessageTemplate = "Argument \"%s\" of %s type \"%s\" is %s"
+	deprecationReasonTemplate         = ": %s"
+	deprecationReplacementTemplate    = ", use %s instead"
+)
+
+// bundledDeprecations is the registry of deprecated and forbidden
+// azurerm resource types and arguments.
+//
+//go:embed deprecations.hcl
+var bundledDeprecations []byte
+
+const bundledDeprecationsFilename = "deprecations.hcl"
+
+// deprecationsConfig is both the format of the bundled registry and the rule
+// config, so the registry is extended and overridden the same way:
+//
+//	resource "azurerm_app_service" {
+//	  replacement = "azurerm_linux_web_app"
+//	}
+//
+//	data "azurerm_key_vault" {
+//	  argument "legacy_argument" {
+//	    forbidden = true
+//	  }
+//	}
+type deprecationsConfig struct {
+	Resources   []deprecatedType `hcl:"resource,block"`
+	DataSources []deprecatedType `hcl:"data,block"`
+}
+
+// deprecatedType is deprecated as a whole unless it lists arguments.
+type deprecatedType struct {
+	Type        string               `hcl:"type,label"`
+	Replacement string               `hcl:"replacement,optional"`
+	Reason      string               `hcl:"reason,optional"`
+	Forbidden   bool                 `hcl:"forbidden,optional"`
+	Arguments   []deprecatedArgument `hcl:"argument,block"`
+}
+
+type deprecatedArgument struct {
+	Name        string `hcl:"name,label"`
+	Replacement string `hcl:"replacement,optional"`
+	Reason      string `hcl:"reason,optional"`
+	Forbidden   bool   `hcl:"forbidden,optional"`
+}
+
+func NewDeprecationsRule() *Rule {
+	return NewRule(
+		"deprecations",
+		Documentation{
+			Description: "Check that resources and data sources do not use deprecated or forbidden azurerm types " +
+				"and arguments from the bundled registry. The rule config adds types or replaces bundled entries of " +
+				"the same types",
+			Example: `resource "azurerm_app_service" "example" {
+  name = "example"
+}
+`,
+			Config: `resource "azurerm_container_group" {
+  forbidden   = true
+  reason      = "containers run in AKS"
+  replacement = "azurerm_kubernetes_cluster"
+}
+
+resource "azurerm_kubernetes_cluster" {
+  argument "default_node_pool.enable_node_public_ip" {
+    forbidden = true
+    reason    = "nodes must not be reachable from the internet"
+  }
+}
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			var config deprecationsConfig
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			registry, err := loadDeprecations(config)
+			if err != nil {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				for _, block := range body.Blocks {
+					if len(block.Labels) != 2 {
+						continue
+					}
+
+					var deprecated map[string]deprecatedType
+					var kind string
+					switch block.Type {
+					case "resource":
+						deprecated, kind = registry.resources, "Resource"
+					case "data":
+						deprecated, kind = registry.dataSources, "Data source"
+					default:
+						continue
+					}
+
+					entry, ok := deprecated[block.Labels[0]]
+					if !ok {
+						continue
+					}
+					if err := checkDeprecatedType(runner, rule, kind, entry, block); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+type deprecationRegistry struct {
+	resources   map[string]deprecatedType
+	dataSources map[string]deprecatedType
+}
+
+// loadDeprecations merges the bundled registry with the rule config,
+// configured types replace bundled ones.
+func loadDeprecations(config deprecationsConfig) (*deprecationRegistry, error) {
+	file, diags := hclparse.NewParser().ParseHCL(bundledDeprecations, bundledDeprecationsFilename)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var bundled deprecationsConfig
+	if diags := gohcl.DecodeBody(file.Body, nil, &bundled); diags.HasErrors() {
+		return nil, diags
+	}
+
+	registry := &deprecationRegistry{
+		resources:   map[string]deprecatedType{},
+		dataSources: map[string]deprecatedType{},
+	}
+	for _, cfg := range []deprecationsConfig{bundled, config} {
+		for _, entry := range cfg.Resources {
+			registry.resources[entry.Type] = entry
+		}
+		for _, entry := range cfg.DataSources {
+			registry.dataSources[entry.Type] = entry
+		}
+	}
+
+	return registry, nil
+}
+
+func checkDeprecatedType(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	kind string,
+	entry deprecatedType,
+	block *hclsyntax.Block,
+) error {
+	if len(entry.Arguments) == 0 {
+		return runner.EmitIssue(
+			rule,
+			deprecationMessage(
+				fmt.Sprintf(deprecatedTypeMessageTemplate, kind, entry.Type, deprecationStatus(entry.Forbidden)),
+				entry.Reason,
+				entry.Replacement,
+			),
+			block.DefRange(),
+		)
+	}
+
+	for _, argument := range entry.Arguments {
+		location, ok := findArgument(block.Body, argument.Name)
+		if !ok {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			rule,
+			deprecationMessage(
+				fmt.Sprintf(
+					deprecatedArgumentMessageTemplate,
+					argument.Name,
+					strings.ToLower(kind),
+					entry.Type,
+					deprecationStatus(argument.Forbidden),
+				),
+				argument.Reason,
+				argument.Replacement,
+			),
+			location,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findArgument looks up an attribute or a nested block by its name,
+// names of nested arguments are separated with dots like
+// "network_profile.docker_bridge_cidr".
+func findArgument(body *hclsyntax.Body, name string) (hcl.Range, bool) {
+	if parts := strings.SplitN(name, ".", 2); len(parts) == 2 {
+		for _, block := range body.Blocks {
+			if block.Type != parts[0] {
+				continue
+			}
+			if location, ok := findArgument(block.Body, parts[1]); ok {
+				return location, true
+			}
+		}
+
+		return hcl.Range{}, false
+	}
+
+	if attr, ok := body.Attributes[name]; ok {
+		return attr.SrcRange, true
+	}
+	for _, block := range body.Blocks {
+		if block.Type == name {
+			return block.DefRange(), true
+		}
+	}
+
+	return hcl.Range{}, false
+}
+
+func deprecationStatus(forbidden bool) string {
+	if forbidden {
+		return forbiddenStatus
+	}
+
+	return deprecatedStatus
+}
+
+func deprecationMessage(message, reason, replacement string) string {
+	if reason != "" {
+		message += fmt.Sprintf(deprecationReasonTemplate, reason)
+	}
+	if replacement != "" {
+		message += fmt.Sprintf(deprecationReplacementTemplate, replacement)
+	}
+
+	return message
+}
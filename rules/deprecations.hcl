@@ -0,0 +1,101 @@
+# Resource types and arguments which should not be used anymore. Blocks
+# with argument blocks only deprecate those arguments, not the whole type.
+# More entries are added with the same blocks in dodo_deprecations rule config.
+
+resource "azurerm_app_service" {
+  replacement = "azurerm_linux_web_app or azurerm_windows_web_app"
+}
+
+resource "azurerm_app_service_plan" {
+  replacement = "azurerm_service_plan"
+}
+
+resource "azurerm_app_service_slot" {
+  replacement = "azurerm_linux_web_app_slot or azurerm_windows_web_app_slot"
+}
+
+resource "azurerm_function_app" {
+  replacement = "azurerm_linux_function_app or azurerm_windows_function_app"
+}
+
+resource "azurerm_function_app_slot" {
+  replacement = "azurerm_linux_function_app_slot or azurerm_windows_function_app_slot"
+}
+
+resource "azurerm_sql_server" {
+  replacement = "azurerm_mssql_server"
+}
+
+resource "azurerm_sql_database" {
+  replacement = "azurerm_mssql_database"
+}
+
+resource "azurerm_sql_elasticpool" {
+  replacement = "azurerm_mssql_elasticpool"
+}
+
+resource "azurerm_sql_firewall_rule" {
+  replacement = "azurerm_mssql_firewall_rule"
+}
+
+resource "azurerm_mysql_server" {
+  reason      = "Azure Database for MySQL single server is retired"
+  replacement = "azurerm_mysql_flexible_server"
+}
+
+resource "azurerm_postgresql_server" {
+  reason      = "Azure Database for PostgreSQL single server is retired"
+  replacement = "azurerm_postgresql_flexible_server"
+}
+
+resource "azurerm_virtual_machine" {
+  replacement = "azurerm_linux_virtual_machine or azurerm_windows_virtual_machine"
+}
+
+resource "azurerm_virtual_machine_scale_set" {
+  replacement = "azurerm_linux_virtual_machine_scale_set or azurerm_windows_virtual_machine_scale_set"
+}
+
+resource "azurerm_template_deployment" {
+  forbidden   = true
+  reason      = "resources created by ARM templates are not tracked in Terraform state"
+  replacement = "azurerm resources"
+}
+
+resource "azurerm_resource_group_template_deployment" {
+  forbidden   = true
+  reason      = "resources created by ARM templates are not tracked in Terraform state"
+  replacement = "azurerm resources"
+}
+
+resource "azurerm_kubernetes_cluster" {
+  argument "api_server_authorized_ip_ranges" {
+    replacement = "api_server_access_profile.authorized_ip_ranges"
+  }
+}
+
+resource "azurerm_storage_account" {
+  argument "allow_blob_public_access" {
+    replacement = "allow_nested_items_to_be_public"
+  }
+}
+
+data "azurerm_app_service" {
+  replacement = "azurerm_linux_web_app or azurerm_windows_web_app"
+}
+
+data "azurerm_app_service_plan" {
+  replacement = "azurerm_service_plan"
+}
+
+data "azurerm_function_app" {
+  replacement = "azurerm_linux_function_app or azurerm_windows_function_app"
+}
+
+data "azurerm_sql_server" {
+  replacement = "azurerm_mssql_server"
+}
+
+data "azurerm_sql_database" {
+  replacement = "azurerm_mssql_database"
+}
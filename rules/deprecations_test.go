@@ -0,0 +1,173 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Deprecations(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `resource "azurerm_linux_web_app" "test" {
+  name = "test"
+}
+
+resource "azurerm_storage_account" "test" {
+  allow_nested_items_to_be_public = false
+}
+
+data "azurerm_mssql_server" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "deprecated and forbidden types",
+			Content: map[string]string{
+				filename: `resource "azurerm_app_service" "test" {
+  name = "test"
+}
+
+data "azurerm_sql_server" "test" {
+  name = "test"
+}
+
+resource "azurerm_template_deployment" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewDeprecationsRule(),
+					Message: `Resource type "azurerm_app_service" is deprecated, ` +
+						`use azurerm_linux_web_app or azurerm_windows_web_app instead`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 38},
+					},
+				},
+				{
+					Rule:    NewDeprecationsRule(),
+					Message: `Data source type "azurerm_sql_server" is deprecated, use azurerm_mssql_server instead`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 33},
+					},
+				},
+				{
+					Rule: NewDeprecationsRule(),
+					Message: `Resource type "azurerm_template_deployment" is forbidden: ` +
+						`resources created by ARM templates are not tracked in Terraform state, use azurerm resources instead`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 9, Column: 1},
+						End:      hcl.Pos{Line: 9, Column: 46},
+					},
+				},
+			},
+		},
+		{
+			Name: "deprecated argument",
+			Content: map[string]string{
+				filename: `resource "azurerm_storage_account" "test" {
+  allow_blob_public_access = false
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewDeprecationsRule(),
+					Message: `Argument "allow_blob_public_access" of resource type "azurerm_storage_account" is deprecated, ` +
+						`use allow_nested_items_to_be_public instead`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured types and nested arguments",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_deprecations" {
+  enabled = true
+
+  resource "azurerm_container_group" {
+    forbidden = true
+  }
+
+  resource "azurerm_storage_account" {
+    argument "network_rules.bypass" {
+      reason = "trusted services are allowed by policy"
+    }
+  }
+}
+`,
+				filename: `resource "azurerm_container_group" "test" {
+  name = "test"
+}
+
+resource "azurerm_storage_account" "test" {
+  allow_blob_public_access = false
+
+  network_rules {
+    bypass = ["AzureServices"]
+  }
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewDeprecationsRule(),
+					Message: `Resource type "azurerm_container_group" is forbidden`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 42},
+					},
+				},
+				{
+					Rule: NewDeprecationsRule(),
+					Message: `Argument "network_rules.bypass" of resource type "azurerm_storage_account" is deprecated: ` +
+						`trusted services are allowed by policy`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 9, Column: 5},
+						End:      hcl.Pos{Line: 9, Column: 31},
+					},
+				},
+			},
+		},
+	}
+	rule := NewDeprecationsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DocsDir is a directory with generated rule documentation
+// relative to the repository root.
+const DocsDir = "docs/rules"
+
+const (
+	readmeFilename    = "README.md"
+	readmeRulesBegin  = "<!-- rules:begin -->"
+	readmeRulesEnd    = "<!-- rules:end -->"
+	generatedDocsNote = "<!-- Code generated by go generate; DO NOT EDIT. -->"
+)
+
+// GenerateDocs renders documentation of all rules. It returns contents of
+// docs/rules/<name>.md files and README.md with the updated rules table
+// keyed by paths relative to the repository root.
+func GenerateDocs(root string) (map[string][]byte, error) {
+	rules := NewRules()
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name() < rules[j].Name()
+	})
+
+	readme, err := os.ReadFile(filepath.Join(root, readmeFilename))
+	if err != nil {
+		return nil, err
+	}
+	readme, err = replaceReadmeRules(readme, renderRulesTable(rules))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := map[string][]byte{readmeFilename: readme}
+	for _, rule := range rules {
+		docs[ruleDocPath(rule.Name())] = renderRuleDoc(rule)
+	}
+
+	return docs, nil
+}
+
+func ruleDocPath(name string) string {
+	return path.Join(DocsDir, name+".md")
+}
+
+func renderRuleDoc(rule *Rule) []byte {
+	doc := rule.Documentation()
+	settings := presets[PresetRecommended](rule)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n", generatedDocsNote)
+	fmt.Fprintf(&b, "# %s\n\n", rule.Name())
+	fmt.Fprintf(&b, "%s.\n\n", doc.Description)
+	fmt.Fprintf(&b, "Severity in the `%s` preset: `%s`.\n", PresetRecommended, strings.ToUpper(settings.severity))
+	if containsString(formattingRules, rule.Name()) {
+		fmt.Fprintf(&b, "The rule is enabled in the `%s` preset.\n", PresetFormattingOnly)
+	}
+
+	fmt.Fprintf(&b, "\n## Example\n\n```hcl\n%s```\n", doc.Example)
+
+	b.WriteString("\n## Configuration\n\n```hcl\n")
+	fmt.Fprintf(&b, "rule \"%s\" {\n  enabled = true\n", rule.Name())
+	if doc.Config != "" {
+		b.WriteString("\n")
+		for _, line := range strings.SplitAfter(doc.Config, "\n") {
+			if strings.TrimSpace(line) != "" {
+				b.WriteString("  ")
+			}
+			b.WriteString(line)
+		}
+	}
+	b.WriteString("}\n```\n")
+
+	return b.Bytes()
+}
+
+func renderRulesTable(rules []*Rule) []byte {
+	var b bytes.Buffer
+	b.WriteString("| Name | Description |\n")
+	b.WriteString("| --- | --- |\n")
+	for _, rule := range rules {
+		fmt.Fprintf(
+			&b,
+			"| [%s](%s) | %s |\n",
+			rule.Name(),
+			ruleDocPath(rule.Name()),
+			rule.Documentation().Description,
+		)
+	}
+
+	return b.Bytes()
+}
+
+// replaceReadmeRules replaces the content between rules markers of README.
+func replaceReadmeRules(readme, table []byte) ([]byte, error) {
+	begin := bytes.Index(readme, []byte(readmeRulesBegin))
+	end := bytes.Index(readme, []byte(readmeRulesEnd))
+	if begin == -1 || end < begin {
+		return nil, fmt.Errorf(
+			"%s should contain %s and %s markers",
+			readmeFilename,
+			readmeRulesBegin,
+			readmeRulesEnd,
+		)
+	}
+
+	var b bytes.Buffer
+	b.Write(readme[:begin+len(readmeRulesBegin)])
+	b.WriteString("\n")
+	b.Write(table)
+	b.Write(readme[end:])
+
+	return b.Bytes(), nil
+}
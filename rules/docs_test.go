@@ -0,0 +1,31 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DocsUpToDate(t *testing.T) {
+	t.Parallel()
+
+	root := ".."
+	docs, err := GenerateDocs(root)
+	require.NoError(t, err)
+
+	for filename, content := range docs {
+		actual, err := os.ReadFile(filepath.Join(root, filename))
+		require.NoError(t, err, "run go generate ./... to create %s", filename)
+		require.Equal(t, string(content), string(actual), "run go generate ./... to update %s", filename)
+	}
+
+	existing, err := filepath.Glob(filepath.Join(root, DocsDir, "*.md"))
+	require.NoError(t, err)
+	for _, filename := range existing {
+		rel, err := filepath.Rel(root, filename)
+		require.NoError(t, err)
+		require.Contains(t, docs, filepath.ToSlash(rel), "%s does not belong to any registered rule", rel)
+	}
+}
@@ -18,6 +18,17 @@ const (
 func NewFileContentRule() *Rule {
 	return NewRule(
 		"file_content",
+		Documentation{
+			Description: "Check that all files looks similarly, mostly focused on vertical alignment",
+			Example: `
+resource "azurerm_resource_group" "example" {
+  name = "example"
+}
+resource "azurerm_resource_group" "another" {
+  name = "another"
+}
+`,
+		},
 		func(runner tflint.Runner, rule tflint.Rule) error {
 			files, err := runner.Files()
 			if err != nil {
@@ -52,7 +63,8 @@ func checkFirstLine(
 		return nil
 	}
 
-	if err := runner.EmitIssue(
+	if err := emitFileIssue(
+		runner,
 		rule,
 		fmt.Sprintf(emptyFirstLineMessageTemplate, filename),
 		hcl.Range{
@@ -78,7 +90,8 @@ func checkLastLine(
 		return nil
 	}
 
-	if err := runner.EmitIssue(
+	if err := emitFileIssue(
+		runner,
 		rule,
 		noNewLineAtTheEndOfFileMessage,
 		hcl.Range{
@@ -107,9 +120,9 @@ func checkSpaceBetweenObjects(
 		return nil
 	}
 
-	tokens, diags := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
-	if diags.HasErrors() {
-		return diags
+	tokens, err := lexFile(filename, file)
+	if err != nil {
+		return err
 	}
 
 	var endOfObjectFound bool
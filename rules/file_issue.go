@@ -0,0 +1,26 @@
+package rules
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// FileIssueEmitter is implemented by runners which distinguish issues of the
+// whole file, like a missing newline at its end, from issues of its lines.
+// TFLint runner knows nothing about them, so there only the issue is emitted.
+type FileIssueEmitter interface {
+	EmitFileIssue(rule tflint.Rule, message string, location hcl.Range) error
+}
+
+func emitFileIssue(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	message string,
+	location hcl.Range,
+) error {
+	if emitter, ok := runner.(FileIssueEmitter); ok {
+		return emitter.EmitFileIssue(rule, message, location)
+	}
+
+	return runner.EmitIssue(rule, message, location)
+}
@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Fix is a replacement of the source bytes covered by Range.
+type Fix struct {
+	Range       hcl.Range
+	Replacement string
+}
+
+// Fixer is implemented by runners which are able to collect autofixes.
+// TFLint runner knows nothing about them, so there only the issue is emitted.
+type Fixer interface {
+	EmitIssueWithFix(rule tflint.Rule, message string, location hcl.Range, fix Fix) error
+}
+
+func emitIssueWithFix(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	message string,
+	location hcl.Range,
+	fix Fix,
+) error {
+	if fixer, ok := runner.(Fixer); ok {
+		return fixer.EmitIssueWithFix(rule, message, location, fix)
+	}
+
+	return runner.EmitIssue(rule, message, location)
+}
+
+// ApplyFixes applies fixes to the source of a single file.
+// Fixes overlapping with already applied ones are skipped.
+func ApplyFixes(src []byte, fixes []Fix) ([]byte, error) {
+	sorted := make([]Fix, len(fixes))
+	copy(sorted, fixes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte > sorted[j].Range.Start.Byte
+	})
+
+	result := make([]byte, len(src))
+	copy(result, src)
+
+	lastStart := len(src) + 1
+	for _, fix := range sorted {
+		start, end := fix.Range.Start.Byte, fix.Range.End.Byte
+		if start < 0 || end > len(src) || start > end {
+			return nil, fmt.Errorf("fix range %s is out of file bounds", fix.Range)
+		}
+		if end > lastStart {
+			continue
+		}
+
+		result = append(
+			result[:start],
+			append([]byte(fix.Replacement), result[end:]...)...,
+		)
+		lastStart = start
+	}
+
+	return result, nil
+}
@@ -1,8 +1,9 @@
 package rules
 
 import (
-	"github.com/hashicorp/hcl/v2"
-	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
@@ -14,46 +15,35 @@ const (
 func NewForeachCountRule() *Rule {
 	return NewRule(
 		"foreach_count",
+		Documentation{
+			Description: "If resource have `for_each` or `count` expression check that they go as first argument " +
+				"and delimited by newline after it",
+			Example: `resource "azurerm_resource_group" "example" {
+  name     = each.key
+  for_each = toset(["first", "second"])
+}
+`,
+		},
 		func(runner tflint.Runner, rule tflint.Rule) error {
-			cfg, _ := runner.Config()
-			for _, res := range cfg.Module.ManagedResources {
-				r, ok := findForeachCount(res)
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				file := files[filename]
+				body, ok := file.Body.(*hclsyntax.Body)
 				if !ok {
 					continue
 				}
 
-				if r.Start.Line-res.DeclRange.End.Line != 1 {
-					if err := runner.EmitIssue(
-						rule,
-						foreachCountFirstArgumentMessage,
-						r,
-					); err != nil {
-						return err
-					}
-
-					return nil
-				}
-
-				attrs, _ := res.Config.JustAttributes()
-				var firstAttr *hcl.Attribute
-				for _, attr := range attrs {
-					if attr.Name == "for_each" ||
-						attr.Name == "count" {
+				lines := strings.Split(string(file.Bytes), "\n")
+				for _, block := range body.Blocks {
+					if block.Type != "resource" {
 						continue
 					}
 
-					if firstAttr == nil ||
-						attr.Range.Start.Line < firstAttr.Range.Start.Line {
-						firstAttr = attr
-					}
-				}
-
-				if firstAttr.Range.Start.Line-r.End.Line != 2 {
-					if err := runner.EmitIssue(
-						rule,
-						foreachCountDelimitedMessage,
-						r,
-					); err != nil {
+					if err := checkForeachCount(runner, rule, lines, block); err != nil {
 						return err
 					}
 				}
@@ -64,17 +54,45 @@ func NewForeachCountRule() *Rule {
 	)
 }
 
-func findForeachCount(res *configs.Resource) (hcl.Range, bool) {
-	var r hcl.Range
-	if res.ForEach != nil {
-		r = res.ForEach.Range()
-	}
-	if res.Count != nil {
-		r = res.Count.Range()
-	}
-	if r.Start.Line == 0 {
-		return r, false
+// checkForeachCount checks that for_each or count goes on the line right after
+// the block header and is followed by an empty line if anything goes after it.
+func checkForeachCount(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	lines []string,
+	block *hclsyntax.Block,
+) error {
+	items := sortedBodyItems(block.Body)
+	for i, item := range items {
+		attr, ok := item.(*hclsyntax.Attribute)
+		if !ok || (attr.Name != "for_each" && attr.Name != "count") {
+			continue
+		}
+
+		r := attr.Expr.Range()
+		if i != 0 || attr.SrcRange.Start.Line-block.OpenBraceRange.End.Line != 1 {
+			return runner.EmitIssue(
+				rule,
+				foreachCountFirstArgumentMessage,
+				r,
+			)
+		}
+
+		if i+1 == len(items) {
+			return nil
+		}
+
+		// Lines are numbered from 1, so lines[r.End.Line] is the next one.
+		if strings.TrimSpace(lines[r.End.Line]) != "" {
+			return runner.EmitIssue(
+				rule,
+				foreachCountDelimitedMessage,
+				r,
+			)
+		}
+
+		return nil
 	}
 
-	return r, true
+	return nil
 }
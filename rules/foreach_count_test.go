@@ -165,6 +165,140 @@ resource "null_resource" "test" {
 				},
 			},
 		},
+		{
+			Name: "no issues block-only body",
+			Content: `
+resource "null_resource" "test" {
+	for_each = toset(["test"])
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+
+resource "null_resource" "another" {
+	count = 1
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "for_each not delimited from block",
+			Content: `
+resource "null_resource" "test" {
+	for_each = toset(["test"])
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewForeachCountRule(),
+					Message: foreachCountDelimitedMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start: hcl.Pos{
+							Line:   3,
+							Column: 13,
+						},
+						End: hcl.Pos{
+							Line:   3,
+							Column: 28,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "no issues heredoc for_each",
+			Content: `
+resource "null_resource" "test" {
+	for_each = toset(split("\\n", trimspace(<<-EOT
+		first
+		second
+	EOT
+	)))
+
+	name = each.key
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "multiline for_each not delimited",
+			Content: `
+resource "null_resource" "test" {
+	for_each = {
+		test = "test"
+	}
+	name = each.key
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewForeachCountRule(),
+					Message: foreachCountDelimitedMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start: hcl.Pos{
+							Line:   3,
+							Column: 13,
+						},
+						End: hcl.Pos{
+							Line:   5,
+							Column: 3,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "issues in every resource",
+			Content: `
+resource "null_resource" "first" {
+	name  = "first"
+	count = 2
+}
+
+resource "null_resource" "second" {
+	count = 2
+	name  = "second"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewForeachCountRule(),
+					Message: foreachCountFirstArgumentMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start: hcl.Pos{
+							Line:   4,
+							Column: 10,
+						},
+						End: hcl.Pos{
+							Line:   4,
+							Column: 11,
+						},
+					},
+				},
+				{
+					Rule:    NewForeachCountRule(),
+					Message: foreachCountDelimitedMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start: hcl.Pos{
+							Line:   8,
+							Column: 10,
+						},
+						End: hcl.Pos{
+							Line:   8,
+							Column: 11,
+						},
+					},
+				},
+			},
+		},
 	}
 	rule := NewForeachCountRule()
 
@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+// Golden tests are discovered in testdata/<rule>/<case>/ directories. Each case
+// contains .tf files, an optional .tflint.hcl and either an expected.json file
+// or inline annotations on the lines where issues are expected:
+//
+//	name = "${var.name}" // want "Interpolation-only expressions are deprecated"
+//
+// Run "go test ./rules -run Test_Golden -update" to regenerate expected.json.
+var update = flag.Bool("update", false, "update expected.json files of golden tests")
+
+const (
+	goldenDir              = "testdata"
+	goldenExpectedFilename = "expected.json"
+)
+
+var wantAnnotationPattern = regexp.MustCompile(`(?://|#)\s*want((?:\s+"(?:[^"\\]|\\.)*")+)`)
+var wantMessagePattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+type goldenPos struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type goldenIssue struct {
+	Rule     string    `json:"rule"`
+	Message  string    `json:"message"`
+	Filename string    `json:"filename"`
+	Start    goldenPos `json:"start"`
+	End      goldenPos `json:"end"`
+}
+
+func Test_Golden(t *testing.T) {
+	t.Parallel()
+
+	rules := map[string]*Rule{}
+	for _, rule := range NewRules() {
+		rules[rule.Name()] = rule
+	}
+
+	cases, err := filepath.Glob(filepath.Join(goldenDir, "*", "*"))
+	require.NoError(t, err)
+
+	for _, dir := range cases {
+		dir := dir
+		ruleName := filepath.Base(filepath.Dir(dir))
+		t.Run(ruleName+"/"+filepath.Base(dir), func(t *testing.T) {
+			t.Parallel()
+
+			rule, ok := rules[ruleName]
+			require.True(t, ok, "unknown rule %s", ruleName)
+
+			files := readGoldenFiles(t, dir)
+			runner := helper.TestRunner(t, files)
+			require.NoError(t, rule.Check(runner))
+
+			actual := make([]goldenIssue, 0, len(runner.Issues))
+			for _, issue := range runner.Issues {
+				actual = append(actual, goldenIssue{
+					Rule:     issue.Rule.Name(),
+					Message:  issue.Message,
+					Filename: issue.Range.Filename,
+					Start:    goldenPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
+					End:      goldenPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
+				})
+			}
+			sortGoldenIssues(actual)
+
+			expectedFile := filepath.Join(dir, goldenExpectedFilename)
+			wants := parseWantAnnotations(files)
+			if len(wants) != 0 {
+				assertWantAnnotations(t, wants, actual)
+				return
+			}
+
+			if *update {
+				content, err := json.MarshalIndent(actual, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(expectedFile, append(content, '\n'), 0o644))
+				return
+			}
+
+			content, err := os.ReadFile(expectedFile)
+			require.NoError(t, err, "run go test with -update to create %s", expectedFile)
+			expected := []goldenIssue{}
+			require.NoError(t, json.Unmarshal(content, &expected))
+			require.Equal(t, expected, actual)
+		})
+	}
+}
+
+// readGoldenFiles reads all files of the case directory keyed by paths
+// relative to it, except expected issues.
+func readGoldenFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || entry.Name() == goldenExpectedFilename {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return files
+}
+
+// parseWantAnnotations returns expected messages keyed by "filename:line".
+func parseWantAnnotations(files map[string]string) map[string][]string {
+	wants := map[string][]string{}
+	for name, content := range files {
+		if name == tflintConfigFilename {
+			continue
+		}
+
+		for i, line := range strings.Split(content, "\n") {
+			match := wantAnnotationPattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			key := name + ":" + strconv.Itoa(i+1)
+			for _, quoted := range wantMessagePattern.FindAllString(match[1], -1) {
+				message, err := strconv.Unquote(quoted)
+				if err != nil {
+					message = quoted
+				}
+				wants[key] = append(wants[key], message)
+			}
+		}
+	}
+
+	return wants
+}
+
+func assertWantAnnotations(t *testing.T, wants map[string][]string, actual []goldenIssue) {
+	t.Helper()
+
+	got := map[string][]string{}
+	for _, issue := range actual {
+		key := issue.Filename + ":" + strconv.Itoa(issue.Start.Line)
+		got[key] = append(got[key], issue.Message)
+	}
+	for key := range wants {
+		sort.Strings(wants[key])
+		sort.Strings(got[key])
+	}
+
+	require.Equal(t, wants, got)
+}
+
+func sortGoldenIssues(issues []goldenIssue) {
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Start.Line != b.Start.Line {
+			return a.Start.Line < b.Start.Line
+		}
+		if a.Start.Column != b.Start.Column {
+			return a.Start.Column < b.Start.Column
+		}
+
+		return a.Message < b.Message
+	})
+}
@@ -0,0 +1,239 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const hardcodedSecretMessageTemplate = "Attribute \"%s\" contains hardcoded %s \"%s\""
+
+const (
+	minSecretLength  = 16
+	minSecretEntropy = 3.5
+	secretMask       = "********"
+)
+
+type secretPattern struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+var secretPatterns = []secretPattern{
+	{
+		kind:    "private key",
+		pattern: regexp.MustCompile(`-----BEGIN ([A-Z]+ )*PRIVATE KEY-----`),
+	},
+	{
+		kind:    "connection string with account key",
+		pattern: regexp.MustCompile(`(?i)AccountKey=[A-Za-z0-9+/]{20,}={0,2}`),
+	},
+	{
+		kind:    "SAS token",
+		pattern: regexp.MustCompile(`(^|[?&])sig=[A-Za-z0-9%+/]{20,}`),
+	},
+	{
+		kind:    "storage account key",
+		pattern: regexp.MustCompile(`(^|[^A-Za-z0-9+/])[A-Za-z0-9+/]{86}==`),
+	},
+	{
+		kind:    "JWT",
+		pattern: regexp.MustCompile(`eyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]+`),
+	},
+}
+
+// secretAttributeNames are parts of attribute names which hold secrets.
+var secretAttributeNames = []string{"password", "secret", "token"}
+
+// secretAttributeSuffixes are last parts of attribute names which hold
+// secrets like access_key, and nonSecretAttributeSuffixes are last parts of
+// attribute names which refer to secrets like key_vault_key_id.
+var (
+	secretAttributeSuffixes    = []string{"key"}
+	nonSecretAttributeSuffixes = []string{"id", "name", "uri"}
+)
+
+func NewHardcodedSecretsRule() *Rule {
+	return NewRule(
+		"hardcoded_secrets",
+		Documentation{
+			Description: "Check that resources, providers, locals and variable defaults do not contain hardcoded keys, " +
+				"SAS tokens, connection strings, JWTs, private keys and high-entropy passwords",
+			Example: `resource "azurerm_mssql_server" "example" {
+  administrator_login_password = "x7Gq2LmP9vRt4WzK8nB3"
+}
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				for _, block := range body.Blocks {
+					var err error
+					switch block.Type {
+					case "resource", "data", "provider", "locals":
+						err = checkSecretsInBody(runner, rule, block.Body)
+					case "variable":
+						if attr, ok := block.Body.Attributes["default"]; ok {
+							err = checkSecretsInAttribute(runner, rule, attr)
+						}
+					}
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkSecretsInBody(runner tflint.Runner, rule tflint.Rule, body *hclsyntax.Body) error {
+	for _, attr := range sortedAttributes(body) {
+		if err := checkSecretsInAttribute(runner, rule, attr); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if err := checkSecretsInBody(runner, rule, block.Body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkSecretsInAttribute(runner tflint.Runner, rule tflint.Rule, attr *hclsyntax.Attribute) error {
+	var err error
+	walkStringLiterals(attr.Name, attr.Expr, func(name, value string) {
+		kind, ok := detectSecret(name, value)
+		if !ok || err != nil {
+			return
+		}
+
+		err = runner.EmitIssue(
+			rule,
+			fmt.Sprintf(hardcodedSecretMessageTemplate, name, kind, secretMask),
+			attr.SrcRange,
+		)
+	})
+
+	return err
+}
+
+// walkStringLiterals visits string literals of the expression along with the
+// name of the attribute or the object key they are assigned to.
+func walkStringLiterals(name string, expr hclsyntax.Expression, visit func(name, value string)) {
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateExpr:
+		if value, ok := quotedLiteral(e); ok {
+			visit(name, value)
+			return
+		}
+		for _, part := range e.Parts {
+			walkStringLiterals(name, part, visit)
+		}
+	case *hclsyntax.LiteralValueExpr:
+		if e.Val.Type() == cty.String && e.Val.IsKnown() && !e.Val.IsNull() {
+			visit(name, e.Val.AsString())
+		}
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			key := hcl.ExprAsKeyword(item.KeyExpr)
+			if key == "" {
+				key, _ = quotedLiteral(item.KeyExpr)
+			}
+			walkStringLiterals(key, item.ValueExpr, visit)
+		}
+	case *hclsyntax.TupleConsExpr:
+		for _, item := range e.Exprs {
+			walkStringLiterals(name, item, visit)
+		}
+	case *hclsyntax.FunctionCallExpr:
+		for _, arg := range e.Args {
+			walkStringLiterals(name, arg, visit)
+		}
+	}
+}
+
+func detectSecret(name, value string) (string, bool) {
+	for _, p := range secretPatterns {
+		if p.pattern.MatchString(value) {
+			return p.kind, true
+		}
+	}
+
+	if isSecretAttributeName(name) && isHighEntropyString(value) {
+		return "high-entropy secret", true
+	}
+
+	return "", false
+}
+
+// isSecretAttributeName reports whether the attribute holds a secret like
+// admin_password or primary_key rather than refers to it like key_name.
+func isSecretAttributeName(name string) bool {
+	parts := strings.Split(strings.ToLower(name), "_")
+	suffix := parts[len(parts)-1]
+	if containsString(nonSecretAttributeSuffixes, suffix) {
+		return false
+	}
+	if containsString(secretAttributeSuffixes, suffix) {
+		return true
+	}
+
+	name = strings.ToLower(name)
+	for _, part := range secretAttributeNames {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isHighEntropyString detects random-looking strings: long enough, without
+// whitespace, with letters of both cases and digits and high Shannon entropy.
+func isHighEntropyString(value string) bool {
+	if len(value) < minSecretLength || strings.ContainsAny(value, " \t\n") {
+		return false
+	}
+
+	var upper, lower, digit bool
+	frequencies := map[rune]float64{}
+	for _, r := range value {
+		upper = upper || unicode.IsUpper(r)
+		lower = lower || unicode.IsLower(r)
+		digit = digit || unicode.IsDigit(r)
+		frequencies[r]++
+	}
+	if !upper || !lower || !digit {
+		return false
+	}
+
+	var entropy float64
+	length := float64(len([]rune(value)))
+	for _, count := range frequencies {
+		p := count / length
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy >= minSecretEntropy
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// SortedFilenames returns names of the files in a stable order.
+func SortedFilenames(files map[string]*hcl.File) []string {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	return filenames
+}
+
+// lexedFiles caches tokens of the last lexed content per file, so rules
+// walking tokens of the same files share a single lexer pass.
+var lexedFiles = struct {
+	sync.Mutex
+	files map[string]lexedFile
+}{files: map[string]lexedFile{}}
+
+type lexedFile struct {
+	src    []byte
+	tokens hclsyntax.Tokens
+}
+
+// lexFile returns tokens of the native syntax file. The tokens are shared
+// between callers and must not be modified.
+func lexFile(filename string, file *hcl.File) (hclsyntax.Tokens, error) {
+	lexedFiles.Lock()
+	defer lexedFiles.Unlock()
+
+	if lexed, ok := lexedFiles.files[filename]; ok && bytes.Equal(lexed.src, file.Bytes) {
+		return lexed.tokens, nil
+	}
+
+	tokens, diags := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	lexedFiles.files[filename] = lexedFile{src: file.Bytes, tokens: tokens}
+
+	return tokens, nil
+}
+
+// sortedAttributes returns attributes of the body in source order.
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+
+	return attrs
+}
+
+// quotedLiteral returns the value of the expression if it is a quoted string
+// without any interpolations.
+func quotedLiteral(expr hcl.Expression) (string, bool) {
+	template, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok || !template.IsStringLiteral() {
+		return "", false
+	}
+
+	value, diags := template.Value(nil)
+	if diags.HasErrors() {
+		return "", false
+	}
+
+	return strings.TrimSpace(value.AsString()), true
+}
+
+// sortedBodyItems returns attributes and nested blocks of the body
+// in source order.
+func sortedBodyItems(body *hclsyntax.Body) []hclsyntax.Node {
+	items := make([]hclsyntax.Node, 0, len(body.Attributes)+len(body.Blocks))
+	for _, attr := range body.Attributes {
+		items = append(items, attr)
+	}
+	for _, block := range body.Blocks {
+		items = append(items, block)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Range().Start.Byte < items[j].Range().Start.Byte
+	})
+
+	return items
+}
@@ -0,0 +1,283 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	heredocIndentedMessage          = "Heredoc should use indented form \"<<-\""
+	heredocDelimiterMessageTemplate = "Heredoc delimiter \"%s\" should be one of: %s"
+	heredocEncodeMessageTemplate    = "Heredoc content is valid %s, use %s function instead"
+)
+
+// minYAMLMappingLines is the number of mapping lines which makes the content
+// of heredocs with other delimiters than YAML look like a YAML document.
+const minYAMLMappingLines = 2
+
+var (
+	defaultHeredocDelimiters = []string{"EOT", "EOF", "JSON", "YAML"}
+	yamlMappingLinePattern   = regexp.MustCompile(`^\s*[\w.-]+:(\s|$)`)
+)
+
+type heredocRuleConfig struct {
+	Delimiters []string `hcl:"delimiters,optional"`
+}
+
+func NewHeredocRule() *Rule {
+	return NewRule(
+		"heredoc",
+		Documentation{
+			Description: "Check that heredocs use indented `<<-` form with allowed delimiters and are not used for " +
+				"JSON/YAML documents instead of `jsonencode`/`yamlencode`",
+			Example: `resource "azurerm_policy_definition" "example" {
+  policy_rule = <<POLICY
+{
+  "if": {},
+  "then": {}
+}
+POLICY
+}
+`,
+			Config: `delimiters = ["EOT", "EOF", "JSON", "YAML"]
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := heredocRuleConfig{Delimiters: defaultHeredocDelimiters}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				if err := checkHeredocs(runner, rule, config, filename, files[filename]); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkHeredocs(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	config heredocRuleConfig,
+	filename string,
+	file *hcl.File,
+) error {
+	if strings.HasSuffix(filename, ".json") {
+		return nil
+	}
+
+	tokens, err := lexFile(filename, file)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Type != hclsyntax.TokenOHeredoc {
+			continue
+		}
+
+		open := tokens[i]
+		var content strings.Builder
+		var interpolated bool
+		for i++; i < len(tokens) && tokens[i].Type != hclsyntax.TokenCHeredoc; i++ {
+			if tokens[i].Type == hclsyntax.TokenStringLit {
+				content.Write(tokens[i].Bytes)
+			} else {
+				interpolated = true
+			}
+		}
+		if i == len(tokens) {
+			break
+		}
+
+		heredoc := heredocToken{
+			open:         open,
+			close:        tokens[i],
+			content:      content.String(),
+			interpolated: interpolated,
+		}
+		if err := checkHeredoc(runner, rule, config, heredoc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type heredocToken struct {
+	open         hclsyntax.Token
+	close        hclsyntax.Token
+	content      string
+	interpolated bool
+}
+
+func (h heredocToken) delimiter() string {
+	return strings.TrimLeft(strings.TrimSpace(string(h.open.Bytes)), "<-")
+}
+
+func (h heredocToken) indented() bool {
+	return strings.HasPrefix(string(h.open.Bytes), "<<-")
+}
+
+// openRange is a range of the opening marker without the trailing newline.
+func (h heredocToken) openRange() hcl.Range {
+	marker := strings.TrimRight(string(h.open.Bytes), "\r\n")
+	r := h.open.Range
+	r.End = hcl.Pos{
+		Line:   r.Start.Line,
+		Column: r.Start.Column + len(marker),
+		Byte:   r.Start.Byte + len(marker),
+	}
+
+	return r
+}
+
+func checkHeredoc(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	config heredocRuleConfig,
+	heredoc heredocToken,
+) error {
+	if !heredoc.indented() {
+		if err := emitHeredocIndentedIssue(runner, rule, heredoc); err != nil {
+			return err
+		}
+	}
+
+	if !containsString(config.Delimiters, heredoc.delimiter()) {
+		if err := runner.EmitIssue(
+			rule,
+			fmt.Sprintf(
+				heredocDelimiterMessageTemplate,
+				heredoc.delimiter(),
+				strings.Join(config.Delimiters, ", "),
+			),
+			heredoc.openRange(),
+		); err != nil {
+			return err
+		}
+	}
+
+	if heredoc.interpolated {
+		return nil
+	}
+	format, function, ok := heredocEncoding(heredoc.delimiter(), heredoc.content)
+	if !ok {
+		return nil
+	}
+
+	return runner.EmitIssue(
+		rule,
+		fmt.Sprintf(heredocEncodeMessageTemplate, format, function),
+		hcl.RangeBetween(heredoc.open.Range, heredoc.close.Range),
+	)
+}
+
+// emitHeredocIndentedIssue emits an issue with a fix only when switching to
+// the indented form does not change the content, i.e. there is a line
+// without leading whitespace.
+func emitHeredocIndentedIssue(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	heredoc heredocToken,
+) error {
+	for _, line := range strings.Split(heredoc.content, "\n") {
+		if line == "" || strings.TrimLeft(line, " \t") != line {
+			continue
+		}
+
+		start := heredoc.open.Range.Start
+		return emitIssueWithFix(
+			runner,
+			rule,
+			heredocIndentedMessage,
+			heredoc.openRange(),
+			Fix{
+				Range: hcl.Range{
+					Filename: heredoc.open.Range.Filename,
+					Start:    start,
+					End: hcl.Pos{
+						Line:   start.Line,
+						Column: start.Column + len("<<"),
+						Byte:   start.Byte + len("<<"),
+					},
+				},
+				Replacement: "<<-",
+			},
+		)
+	}
+
+	return runner.EmitIssue(rule, heredocIndentedMessage, heredoc.openRange())
+}
+
+// heredocEncoding detects whether the content is a JSON or YAML document
+// which should be built by the encoding function instead. Almost any text is
+// valid YAML, so it is reported only for the YAML delimiter or content with
+// several mapping lines. Content starting with a comment header like
+// "#cloud-config" is skipped as the encoding functions cannot produce it.
+func heredocEncoding(delimiter, content string) (string, string, bool) {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return "", "", false
+	}
+
+	var value interface{}
+	if json.Unmarshal([]byte(content), &value) == nil {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return "JSON", "jsonencode", true
+		}
+
+		return "", "", false
+	}
+
+	if delimiter != "YAML" && countYAMLMappingLines(content) < minYAMLMappingLines {
+		return "", "", false
+	}
+	if yaml.Unmarshal([]byte(content), &value) == nil {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return "YAML", "yamlencode", true
+		}
+	}
+
+	return "", "", false
+}
+
+// countYAMLMappingLines returns the number of lines like "key: value".
+func countYAMLMappingLines(content string) int {
+	count := 0
+	for _, line := range strings.Split(content, "\n") {
+		if yamlMappingLinePattern.MatchString(line) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
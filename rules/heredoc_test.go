@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Heredoc(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  script = <<-EOT
+    echo "${var.name}"
+  EOT
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "not indented heredoc",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  script = <<EOT
+echo "test"
+EOT
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewHeredocRule(),
+					Message: heredocIndentedMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 2, Column: 17},
+					},
+				},
+			},
+			Fixed: `resource "null_resource" "test" {
+  script = <<-EOT
+echo "test"
+EOT
+}
+`,
+		},
+		{
+			Name: "delimiter not in allowlist",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  script = <<-script
+    echo "test"
+  script
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewHeredocRule(),
+					Message: fmt.Sprintf(
+						heredocDelimiterMessageTemplate,
+						"script",
+						strings.Join(defaultHeredocDelimiters, ", "),
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 2, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured delimiters",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_heredoc" {
+  enabled    = true
+  delimiters = ["SCRIPT"]
+}
+`,
+				filename: `resource "null_resource" "test" {
+  script = <<-SCRIPT
+    echo "test"
+  SCRIPT
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "json content",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  policy = <<-JSON
+    {
+      "key": "value"
+    }
+  JSON
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewHeredocRule(),
+					Message: fmt.Sprintf(heredocEncodeMessageTemplate, "JSON", "jsonencode"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 6, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "yaml content",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  values = <<-YAML
+    key: value
+    list:
+      - item
+  YAML
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewHeredocRule(),
+					Message: fmt.Sprintf(heredocEncodeMessageTemplate, "YAML", "yamlencode"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 6, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "yaml content with other delimiter",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  values = <<-EOT
+    key: value
+    other: value
+  EOT
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewHeredocRule(),
+					Message: fmt.Sprintf(heredocEncodeMessageTemplate, "YAML", "yamlencode"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 5, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			Name: "plain text looking like yaml",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  description = <<-EOT
+    Owner: platform team
+  EOT
+
+  script = <<-EOT
+    - step
+    echo done
+  EOT
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "yaml content with comment header",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  custom_data = <<-YAML
+    #cloud-config
+    packages:
+      - nginx
+    runcmd:
+      - systemctl start nginx
+  YAML
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+	}
+	rule := NewHeredocRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newFixRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+
+			if tc.Fixed == "" {
+				return
+			}
+			fixed, err := ApplyFixes([]byte(tc.Content[filename]), runner.Fixes)
+			require.NoError(t, err)
+			require.Equal(t, tc.Fixed, string(fixed))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	interpolationOnlyMessage       = "Interpolation-only expressions are deprecated, use the expression without \"${}\""
+	quotedReferenceMessageTemplate = "Quoted references are deprecated in %s, use %s instead"
+	quotedTypeMessageTemplate      = "Quoted type constraints are deprecated, use %s instead"
+)
+
+// legacyQuotedTypes maps quoted type constraints to their modern form.
+var legacyQuotedTypes = map[string]string{
+	"string": "string",
+	"list":   "list(string)",
+	"map":    "map(string)",
+}
+
+func NewLegacySyntaxRule() *Rule {
+	return NewRule(
+		"legacy_syntax",
+		Documentation{
+			Description: "Check that there are no interpolation-only expressions, quoted references in " +
+				"`depends_on`/`ignore_changes` and quoted type constraints",
+			Example: `variable "name" {
+  type = "string"
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "${var.name}"
+
+  depends_on = ["azurerm_resource_group.another"]
+}
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				file := files[filename]
+				body, ok := file.Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				if err := checkLegacySyntaxBody(runner, rule, file.Bytes, "", body); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkLegacySyntaxBody(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	src []byte,
+	blockType string,
+	body *hclsyntax.Body,
+) error {
+	for _, attr := range sortedAttributes(body) {
+		var err error
+		switch {
+		case blockType == "variable" && attr.Name == "type":
+			err = checkQuotedType(runner, rule, attr)
+		case blockType == "lifecycle" && attr.Name == "ignore_changes",
+			blockType != "" && attr.Name == "depends_on":
+			err = checkQuotedReferences(runner, rule, attr)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := checkInterpolationOnly(runner, rule, src, attr.Expr); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if err := checkLegacySyntaxBody(runner, rule, src, block.Type, block.Body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkInterpolationOnly(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	src []byte,
+	expr hclsyntax.Expression,
+) error {
+	var err error
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		wrap, ok := node.(*hclsyntax.TemplateWrapExpr)
+		if !ok || err != nil {
+			return nil
+		}
+
+		wrapped := wrap.Wrapped.Range()
+		err = emitIssueWithFix(
+			runner,
+			rule,
+			interpolationOnlyMessage,
+			wrap.Range(),
+			Fix{
+				Range:       wrap.Range(),
+				Replacement: string(wrapped.SliceBytes(src)),
+			},
+		)
+
+		return nil
+	})
+
+	return err
+}
+
+func checkQuotedReferences(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	attr *hclsyntax.Attribute,
+) error {
+	tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return nil
+	}
+
+	for _, expr := range tuple.Exprs {
+		ref, ok := quotedLiteral(expr)
+		if !ok {
+			continue
+		}
+		if _, diags := hclsyntax.ParseTraversalAbs([]byte(ref), "", hcl.InitialPos); diags.HasErrors() {
+			continue
+		}
+
+		if err := emitIssueWithFix(
+			runner,
+			rule,
+			fmt.Sprintf(quotedReferenceMessageTemplate, attr.Name, ref),
+			expr.Range(),
+			Fix{
+				Range:       expr.Range(),
+				Replacement: ref,
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkQuotedType(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	attr *hclsyntax.Attribute,
+) error {
+	name, ok := quotedLiteral(attr.Expr)
+	if !ok {
+		return nil
+	}
+	modern, ok := legacyQuotedTypes[name]
+	if !ok {
+		return nil
+	}
+
+	return emitIssueWithFix(
+		runner,
+		rule,
+		fmt.Sprintf(quotedTypeMessageTemplate, modern),
+		attr.Expr.Range(),
+		Fix{
+			Range:       attr.Expr.Range(),
+			Replacement: modern,
+		},
+	)
+}
@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_LegacySyntax(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "no issues",
+			Content: `variable "name" {
+  type = string
+}
+
+resource "null_resource" "test" {
+  name        = var.name
+  description = "name is ${var.name}"
+
+  depends_on = [null_resource.other]
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "interpolation-only expression",
+			Content: `resource "null_resource" "test" {
+  name = "${var.name}"
+  tags = {
+    name = "${var.name}"
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewLegacySyntaxRule(),
+					Message: interpolationOnlyMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 10},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+				{
+					Rule:    NewLegacySyntaxRule(),
+					Message: interpolationOnlyMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 4, Column: 12},
+						End:      hcl.Pos{Line: 4, Column: 25},
+					},
+				},
+			},
+			Fixed: `resource "null_resource" "test" {
+  name = var.name
+  tags = {
+    name = var.name
+  }
+}
+`,
+		},
+		{
+			Name: "quoted references",
+			Content: `resource "null_resource" "test" {
+  depends_on = ["null_resource.other"]
+
+  lifecycle {
+    ignore_changes = ["tags"]
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewLegacySyntaxRule(),
+					Message: fmt.Sprintf(quotedReferenceMessageTemplate, "depends_on", "null_resource.other"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 17},
+						End:      hcl.Pos{Line: 2, Column: 38},
+					},
+				},
+				{
+					Rule:    NewLegacySyntaxRule(),
+					Message: fmt.Sprintf(quotedReferenceMessageTemplate, "ignore_changes", "tags"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 23},
+						End:      hcl.Pos{Line: 5, Column: 29},
+					},
+				},
+			},
+			Fixed: `resource "null_resource" "test" {
+  depends_on = [null_resource.other]
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
+`,
+		},
+		{
+			Name: "quoted type",
+			Content: `variable "tags" {
+  type = "map"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewLegacySyntaxRule(),
+					Message: fmt.Sprintf(quotedTypeMessageTemplate, "map(string)"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 10},
+						End:      hcl.Pos{Line: 2, Column: 15},
+					},
+				},
+			},
+			Fixed: `variable "tags" {
+  type = map(string)
+}
+`,
+		},
+	}
+	rule := NewLegacySyntaxRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newSyntaxRunner(t, map[string]string{filename: tc.Content})
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+
+			if tc.Fixed == "" {
+				return
+			}
+			fixed, err := ApplyFixes([]byte(tc.Content), runner.Fixes)
+			require.NoError(t, err)
+			require.Equal(t, tc.Fixed, string(fixed))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const allowDestroyAnnotation = "allow-destroy"
+
+const (
+	preventDestroyMessageTemplate   = "Resource \"%s\" holds data and should have lifecycle prevent_destroy = true"
+	ignoreAllChangesMessageTemplate = "Resource \"%s\" should not ignore all changes"
+)
+
+var defaultProtectedResourceTypes = []string{
+	"azurerm_cosmosdb_account",
+	"azurerm_key_vault",
+	"azurerm_kubernetes_cluster",
+	"azurerm_mssql_database",
+	"azurerm_mssql_server",
+	"azurerm_mysql_flexible_server",
+	"azurerm_mysql_server",
+	"azurerm_postgresql_flexible_server",
+	"azurerm_postgresql_server",
+	"azurerm_sql_database",
+	"azurerm_sql_server",
+	"azurerm_storage_account",
+}
+
+type lifecycleSafetyRuleConfig struct {
+	ProtectedTypes []string `hcl:"protected_types,optional"`
+}
+
+func NewLifecycleSafetyRule() *Rule {
+	return NewRule(
+		"lifecycle_safety",
+		Documentation{
+			Description: "Check that stateful Azure resources have `prevent_destroy = true` unless annotated with " +
+				"`// dodo:allow-destroy`, and no resource ignores all changes",
+			Example: `resource "azurerm_storage_account" "example" {
+  name = "example"
+
+  lifecycle {
+    ignore_changes = all
+  }
+}
+`,
+			Config: `protected_types = ["azurerm_storage_account", "azurerm_key_vault"]
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := lifecycleSafetyRuleConfig{ProtectedTypes: defaultProtectedResourceTypes}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			annotated, err := getAnnotatedLines(runner, allowDestroyAnnotation)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := runner.Config()
+			if err != nil {
+				return err
+			}
+
+			addrs := make([]string, 0, len(cfg.Module.ManagedResources))
+			for addr := range cfg.Module.ManagedResources {
+				addrs = append(addrs, addr)
+			}
+			sort.Strings(addrs)
+
+			for _, addr := range addrs {
+				res := cfg.Module.ManagedResources[addr]
+				if res.Managed != nil && res.Managed.IgnoreAllChanges {
+					if err := runner.EmitIssue(
+						rule,
+						fmt.Sprintf(ignoreAllChangesMessageTemplate, addr),
+						res.DeclRange,
+					); err != nil {
+						return err
+					}
+				}
+
+				if !containsString(config.ProtectedTypes, res.Type) ||
+					annotated[res.DeclRange.Filename][res.DeclRange.Start.Line] ||
+					(res.Managed != nil && res.Managed.PreventDestroy) {
+					continue
+				}
+
+				if err := runner.EmitIssue(
+					rule,
+					fmt.Sprintf(preventDestroyMessageTemplate, addr),
+					res.DeclRange,
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
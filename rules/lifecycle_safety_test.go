@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_LifecycleSafety(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `resource "azurerm_storage_account" "test" {
+  name = "test"
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+// Temporary cluster for load tests.
+// dodo:allow-destroy
+resource "azurerm_kubernetes_cluster" "test" {
+  name = "test"
+}
+
+resource "azurerm_resource_group" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "annotations in the leading comment block and on the resource line",
+			Content: map[string]string{
+				filename: `// dodo:allow-destroy
+// Temporary cluster for load tests,
+// it is recreated for every run.
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name = "test"
+}
+
+resource "azurerm_storage_account" "test" { // dodo:allow-destroy
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no prevent_destroy and ignore all changes",
+			Content: map[string]string{
+				filename: `resource "azurerm_key_vault" "test" {
+  name = "test"
+
+  lifecycle {
+    ignore_changes = all
+  }
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLifecycleSafetyRule(),
+					Message: fmt.Sprintf(ignoreAllChangesMessageTemplate, "azurerm_key_vault.test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 36},
+					},
+				},
+				{
+					Rule:    NewLifecycleSafetyRule(),
+					Message: fmt.Sprintf(preventDestroyMessageTemplate, "azurerm_key_vault.test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 36},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured protected types",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_lifecycle_safety" {
+  enabled         = true
+  protected_types = ["azurerm_redis_cache"]
+}
+`,
+				filename: `resource "azurerm_storage_account" "test" {
+  name = "test"
+}
+
+resource "azurerm_redis_cache" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLifecycleSafetyRule(),
+					Message: fmt.Sprintf(preventDestroyMessageTemplate, "azurerm_redis_cache.test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 38},
+					},
+				},
+			},
+		},
+	}
+	rule := NewLifecycleSafetyRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
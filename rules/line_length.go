@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const defaultMaxLineLength = 120
+
+const (
+	lineLengthMessageTemplate           = "Line is %d characters long, maximum is %d"
+	invalidMaxLineLengthMessageTemplate = "max should be positive, got %d"
+)
+
+type lineLengthRuleConfig struct {
+	Max int `hcl:"max,optional"`
+}
+
+func NewLineLengthRule() *Rule {
+	return NewRule(
+		"line_length",
+		Documentation{
+			Description: "Check that lines are not longer than configured maximum. Heredocs and strings with URLs are exempt",
+			Example: `resource "azurerm_resource_group" "example" {
+  name = join("-", [var.project, var.environment, var.location, var.component, var.instance, "resource-group"])
+}
+`,
+			Config: `max = 120
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := lineLengthRuleConfig{Max: defaultMaxLineLength}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+			if config.Max <= 0 {
+				return fmt.Errorf(invalidMaxLineLengthMessageTemplate, config.Max)
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for filename, file := range files {
+				if err := checkLineLength(runner, rule, config.Max, filename, file); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkLineLength(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	max int,
+	filename string,
+	file *hcl.File,
+) error {
+	if strings.HasSuffix(filename, ".json") {
+		return nil
+	}
+
+	exempt, err := lineLengthExemptLines(filename, file)
+	if err != nil {
+		return err
+	}
+
+	var offset int
+	for i, line := range strings.Split(string(file.Bytes), "\n") {
+		lineStart := offset
+		offset += len(line) + 1
+
+		line = strings.TrimSuffix(line, "\r")
+		length := utf8.RuneCountInString(line)
+		if length <= max || exempt[i+1] {
+			continue
+		}
+
+		overflow := len(string([]rune(line)[:max]))
+		if err := runner.EmitIssue(
+			rule,
+			fmt.Sprintf(lineLengthMessageTemplate, length, max),
+			hcl.Range{
+				Filename: filename,
+				Start: hcl.Pos{
+					Line:   i + 1,
+					Column: max + 1,
+					Byte:   lineStart + overflow,
+				},
+				End: hcl.Pos{
+					Line:   i + 1,
+					Column: length + 1,
+					Byte:   lineStart + len(line),
+				},
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lineLengthExemptLines returns lines which are allowed to be long:
+// heredoc contents and lines with string literals containing URLs.
+func lineLengthExemptLines(filename string, file *hcl.File) (map[int]bool, error) {
+	tokens, diags := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	exempt := map[int]bool{}
+	var heredocStart int
+	for _, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenOHeredoc:
+			heredocStart = token.Range.Start.Line
+		case hclsyntax.TokenCHeredoc:
+			for line := heredocStart + 1; line <= token.Range.Start.Line; line++ {
+				exempt[line] = true
+			}
+		case hclsyntax.TokenQuotedLit:
+			if strings.Contains(string(token.Bytes), "://") {
+				exempt[token.Range.Start.Line] = true
+			}
+		}
+	}
+
+	return exempt, nil
+}
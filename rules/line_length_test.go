@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_LineLength(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+		Error    bool
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "long line",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  description = "Lorem ipsum dolor sit amet, consectetur adipiscing elit, ` +
+					`sed do eiusmod tempor incididunt ut labore etc"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLineLengthRule(),
+					Message: fmt.Sprintf(lineLengthMessageTemplate, 121, defaultMaxLineLength),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 121},
+						End:      hcl.Pos{Line: 2, Column: 122},
+					},
+				},
+			},
+		},
+		{
+			Name: "no issues with url",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  url = "https://github.com/dodopizza/tflint-ruleset-dodo/blob/main/rules/line_length.go?some=very&long=query#L1"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no issues with heredoc",
+			Content: map[string]string{
+				filename: `resource "null_resource" "test" {
+  description = <<-EOT
+    Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore
+  EOT
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "configured maximum",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_line_length" {
+  enabled = true
+  max     = 30
+}
+`,
+				filename: `resource "null_resource" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLineLengthRule(),
+					Message: fmt.Sprintf(lineLengthMessageTemplate, 33, 30),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 31},
+						End:      hcl.Pos{Line: 1, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "negative maximum",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_line_length" {
+  enabled = true
+  max     = -1
+}
+`,
+				filename: `resource "null_resource" "test" {
+  name = "test"
+}
+`,
+			},
+			Error: true,
+		},
+	}
+	rule := NewLineLengthRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+
+			if tc.Error {
+				require.Error(t, rule.Check(runner))
+				return
+			}
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
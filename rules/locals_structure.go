@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	multipleLocalsMessageTemplate  = "There should be only one locals block in %s file"
+	localsWrongFileMessageTemplate = "locals block should be moved from %s to %s file"
+	localsSortMessageTemplate      = "Local value \"%s\" should go before \"%s\" to keep locals sorted by name"
+	localAliasMessageTemplate      = "Local value \"%s\" only aliases \"%s\", use the variable directly"
+)
+
+type localsStructureRuleConfig struct {
+	File string `hcl:"file,optional"`
+}
+
+func NewLocalsStructureRule() *Rule {
+	return NewRule(
+		"locals_structure",
+		Documentation{
+			Description: "Check that there is one `locals` block per file (or all of them are in configured file), " +
+				"locals are sorted by name and do not just alias variables",
+			Example: `locals {
+  name     = var.name
+  location = "westeurope"
+}
+`,
+			Config: `file = "locals.tf"
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := localsStructureRuleConfig{}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				if err := checkLocalsBlocks(runner, rule, config, filename, body); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkLocalsBlocks(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	config localsStructureRuleConfig,
+	filename string,
+	body *hclsyntax.Body,
+) error {
+	var found bool
+	for _, block := range body.Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+
+		switch {
+		case config.File != "" && filepath.Base(filename) != config.File:
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(
+					localsWrongFileMessageTemplate,
+					filename,
+					filepath.Join(filepath.Dir(filename), config.File),
+				),
+				block.DefRange(),
+			); err != nil {
+				return err
+			}
+		case found && config.File == "":
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(multipleLocalsMessageTemplate, filename),
+				block.DefRange(),
+			); err != nil {
+				return err
+			}
+		}
+		found = true
+
+		if err := checkLocals(runner, rule, block.Body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkLocals(runner tflint.Runner, rule tflint.Rule, body *hclsyntax.Body) error {
+	var previous *hclsyntax.Attribute
+	for _, attr := range sortedAttributes(body) {
+		if previous != nil && attr.Name < previous.Name {
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(localsSortMessageTemplate, attr.Name, previous.Name),
+				attr.NameRange,
+			); err != nil {
+				return err
+			}
+		}
+		previous = attr
+
+		if variable, ok := variableAlias(attr.Expr); ok {
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(localAliasMessageTemplate, attr.Name, variable),
+				attr.SrcRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// variableAlias returns the variable address if the expression is just
+// a reference to the variable, e.g. "var.name".
+func variableAlias(expr hclsyntax.Expression) (string, bool) {
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversal.Traversal) != 2 || traversal.Traversal.RootName() != "var" {
+		return "", false
+	}
+
+	attr, ok := traversal.Traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+
+	return "var." + attr.Name, true
+}
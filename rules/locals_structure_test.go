@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_LocalsStructure(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `locals {
+  location = "westeurope"
+  name     = "${var.prefix}-rg"
+  tags     = merge(var.tags, { name = var.name })
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "multiple locals blocks",
+			Content: map[string]string{
+				filename: `locals {
+  a = "a"
+}
+
+locals {
+  b = "b"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLocalsStructureRule(),
+					Message: fmt.Sprintf(multipleLocalsMessageTemplate, filename),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "locals not in configured file",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_locals_structure" {
+  enabled = true
+  file    = "locals.tf"
+}
+`,
+				"locals.tf": `locals {
+  a = "a"
+}
+`,
+				filename: `locals {
+  b = "b"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLocalsStructureRule(),
+					Message: fmt.Sprintf(localsWrongFileMessageTemplate, filename, "locals.tf"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "unsorted locals and variable alias",
+			Content: map[string]string{
+				filename: `locals {
+  name     = var.name
+  location = "westeurope"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewLocalsStructureRule(),
+					Message: fmt.Sprintf(localAliasMessageTemplate, "name", "var.name"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 22},
+					},
+				},
+				{
+					Rule:    NewLocalsStructureRule(),
+					Message: fmt.Sprintf(localsSortMessageTemplate, "location", "name"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 11},
+					},
+				},
+			},
+		},
+	}
+	rule := NewLocalsStructureRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
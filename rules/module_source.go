@@ -0,0 +1,230 @@
+package rules
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const defaultRegistryHost = "registry.terraform.io"
+
+const (
+	moduleSourceRefMissingMessageTemplate = "Module \"%s\" git source should pin \"?ref=\" to a tag or commit SHA"
+	moduleSourceBranchRefMessageTemplate  = "Module \"%s\" git source ref \"%s\" should be a tag or commit SHA, " +
+		"not a branch"
+	moduleVersionMessageTemplate          = "Module \"%s\" from registry should have exact or \"~>\" version constraint"
+	moduleSourceNotAllowedMessageTemplate = "Module \"%s\" source \"%s\" is not allowed, allowed sources: %s"
+	invalidTagPatternMessageTemplate      = "tag_pattern %q is not a valid regular expression: %w"
+)
+
+// defaultGitTagRefPattern matches refs with digits like "v1.2.0",
+// "network-v1.2.0" or "release-2024", refs like "main" are branches.
+const defaultGitTagRefPattern = `[0-9]`
+
+var (
+	gitCommitRefPattern   = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+	registrySourcePattern = regexp.MustCompile(
+		`^([a-z0-9-]+(\.[a-z0-9-]+)+/)?` + `[A-Za-z0-9_-]+/[A-Za-z0-9_-]+/[a-z0-9]+` + `(//.*)?$`,
+	)
+)
+
+type moduleSourceRuleConfig struct {
+	AllowedSources []string `hcl:"allowed_sources,optional"`
+	TagPattern     string   `hcl:"tag_pattern,optional"`
+}
+
+func NewModuleSourceRule() *Rule {
+	return NewRule(
+		"module_source",
+		Documentation{
+			Description: "Check that git module sources pin `?ref=` to a tag or commit SHA, registry modules have " +
+				"exact or `~>` version and sources are allowed. Refs matching `tag_pattern` are tags, " +
+				"by default any ref with digits, and all sources are allowed unless `allowed_sources` is set",
+			Example: `module "network" {
+  source = "github.com/dodopizza/terraform-modules//network?ref=main"
+}
+`,
+			Config: `allowed_sources = ["github.com/dodopizza"]
+tag_pattern     = "^(v|[a-z-]+-v)[0-9]"
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := moduleSourceRuleConfig{TagPattern: defaultGitTagRefPattern}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+			tagPattern, err := regexp.Compile(config.TagPattern)
+			if err != nil {
+				return fmt.Errorf(invalidTagPatternMessageTemplate, config.TagPattern, err)
+			}
+
+			cfg, err := runner.Config()
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(cfg.Module.ModuleCalls))
+			for name := range cfg.Module.ModuleCalls {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				call := cfg.Module.ModuleCalls[name]
+				if err := checkModuleSource(runner, rule, config, tagPattern, call); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkModuleSource(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	config moduleSourceRuleConfig,
+	tagPattern *regexp.Regexp,
+	call *configs.ModuleCall,
+) error {
+	source := call.SourceAddr
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		return nil
+	}
+
+	if normalized := normalizeModuleSource(source); !isAllowedModuleSource(config.AllowedSources, normalized) {
+		if err := runner.EmitIssue(
+			rule,
+			fmt.Sprintf(
+				moduleSourceNotAllowedMessageTemplate,
+				call.Name,
+				source,
+				strings.Join(config.AllowedSources, ", "),
+			),
+			call.SourceAddrRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case isGitModuleSource(source):
+		return checkGitModuleRef(runner, rule, tagPattern, call)
+	case registrySourcePattern.MatchString(source):
+		return checkRegistryModuleVersion(runner, rule, call)
+	}
+
+	return nil
+}
+
+func checkGitModuleRef(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	tagPattern *regexp.Regexp,
+	call *configs.ModuleCall,
+) error {
+	var ref string
+	if i := strings.Index(call.SourceAddr, "?"); i != -1 {
+		query, err := url.ParseQuery(call.SourceAddr[i+1:])
+		if err == nil {
+			ref = query.Get("ref")
+		}
+	}
+
+	if ref == "" {
+		return runner.EmitIssue(
+			rule,
+			fmt.Sprintf(moduleSourceRefMissingMessageTemplate, call.Name),
+			call.SourceAddrRange,
+		)
+	}
+
+	if !tagPattern.MatchString(ref) && !gitCommitRefPattern.MatchString(ref) {
+		return runner.EmitIssue(
+			rule,
+			fmt.Sprintf(moduleSourceBranchRefMessageTemplate, call.Name, ref),
+			call.SourceAddrRange,
+		)
+	}
+
+	return nil
+}
+
+func checkRegistryModuleVersion(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	call *configs.ModuleCall,
+) error {
+	constraints := call.Version.Required
+	if len(constraints) == 1 {
+		constraint := strings.TrimSpace(constraints[0].String())
+		if strings.HasPrefix(constraint, "~>") ||
+			strings.HasPrefix(constraint, "=") ||
+			!strings.ContainsAny(constraint[:1], "<>!") {
+			return nil
+		}
+	}
+
+	r := call.Version.DeclRange
+	if len(constraints) == 0 {
+		r = call.DeclRange
+	}
+
+	return runner.EmitIssue(
+		rule,
+		fmt.Sprintf(moduleVersionMessageTemplate, call.Name),
+		r,
+	)
+}
+
+func isGitModuleSource(source string) bool {
+	return strings.HasPrefix(source, "git::") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.HasPrefix(source, "github.com/") ||
+		strings.HasPrefix(source, "bitbucket.org/")
+}
+
+// normalizeModuleSource converts the module source to the "host/path" form
+// without protocols, credentials and query.
+func normalizeModuleSource(source string) string {
+	source = strings.TrimPrefix(source, "git::")
+	if i := strings.Index(source, "?"); i != -1 {
+		source = source[:i]
+	}
+	if i := strings.Index(source, "://"); i != -1 {
+		source = source[i+len("://"):]
+	} else if i := strings.Index(source, "@"); i != -1 {
+		// scp-like "git@host:path" sources separate the path by a colon.
+		source = strings.Replace(source[i+1:], ":", "/", 1)
+	}
+	if i := strings.Index(source, "@"); i != -1 {
+		source = source[i+1:]
+	}
+
+	if registrySourcePattern.MatchString(source) && strings.Count(strings.Split(source, "//")[0], "/") == 2 {
+		source = defaultRegistryHost + "/" + source
+	}
+
+	return source
+}
+
+func isAllowedModuleSource(allowed []string, source string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, prefix := range allowed {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if source == prefix || strings.HasPrefix(source, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_ModuleSource(t *testing.T) {
+	t.Parallel()
+
+	registryConfig := `
+rule "dodo_module_source" {
+  enabled         = true
+  allowed_sources = ["github.com/dodopizza", "registry.terraform.io/Azure", "git.example.com:8443/platform"]
+}
+`
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				".tflint.hcl": registryConfig,
+				filename: `module "local" {
+  source = "./modules/local"
+}
+
+module "tag" {
+  source = "git::https://github.com/dodopizza/terraform-modules.git//aks?ref=v1.2.0"
+}
+
+module "commit" {
+  source = "git@github.com:dodopizza/terraform-modules.git?ref=4f1c2d3"
+}
+
+module "prefixed_tag" {
+  source = "git::https://git.example.com:8443/platform/modules.git//network?ref=network-v1.2.0"
+}
+
+module "release_tag" {
+  source = "github.com/dodopizza/terraform-modules?ref=release-2024"
+}
+
+module "registry" {
+  source  = "Azure/aks/azurerm"
+  version = "~> 4.0"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "git source without ref",
+			Content: map[string]string{
+				filename: `module "test" {
+  source = "github.com/dodopizza/terraform-modules"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewModuleSourceRule(),
+					Message: fmt.Sprintf(moduleSourceRefMissingMessageTemplate, "test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 2, Column: 52},
+					},
+				},
+			},
+		},
+		{
+			Name: "git source with branch ref",
+			Content: map[string]string{
+				filename: `module "test" {
+  source = "github.com/dodopizza/terraform-modules?ref=main"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewModuleSourceRule(),
+					Message: fmt.Sprintf(moduleSourceBranchRefMessageTemplate, "test", "main"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 2, Column: 61},
+					},
+				},
+			},
+		},
+		{
+			Name: "registry module with open-ended version",
+			Content: map[string]string{
+				".tflint.hcl": registryConfig,
+				filename: `module "test" {
+  source  = "Azure/aks/azurerm"
+  version = ">= 4.0"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewModuleSourceRule(),
+					Message: fmt.Sprintf(moduleVersionMessageTemplate, "test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 3, Column: 13},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "public registry module is allowed by default",
+			Content: map[string]string{
+				filename: `module "test" {
+  source  = "Azure/aks/azurerm"
+  version = "4.0.0"
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "configured tag pattern",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_module_source" {
+  enabled     = true
+  tag_pattern = "^v[0-9]"
+}
+`,
+				filename: `module "test" {
+  source = "github.com/dodopizza/terraform-modules?ref=release-2024"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewModuleSourceRule(),
+					Message: fmt.Sprintf(moduleSourceBranchRefMessageTemplate, "test", "release-2024"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 12},
+						End:      hcl.Pos{Line: 2, Column: 69},
+					},
+				},
+			},
+		},
+		{
+			Name: "source is not allowed",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_module_source" {
+  enabled         = true
+  allowed_sources = ["github.com/dodopizza"]
+}
+`,
+				filename: `module "test" {
+  source  = "Azure/aks/azurerm"
+  version = "4.0.0"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewModuleSourceRule(),
+					Message: fmt.Sprintf(
+						moduleSourceNotAllowedMessageTemplate,
+						"test",
+						"Azure/aks/azurerm",
+						"github.com/dodopizza",
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 13},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+			},
+		},
+	}
+	rule := NewModuleSourceRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
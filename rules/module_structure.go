@@ -23,6 +23,17 @@ const (
 func NewModuleStructureRule() *Rule {
 	return NewRule(
 		"module_structure",
+		Documentation{
+			Description: "Check that variables and outputs are placed into `variables.tf` and `outputs.tf` files " +
+				"and these files contain nothing else",
+			Example: `// main.tf
+variable "name" {}
+
+output "id" {
+  value = azurerm_resource_group.example.id
+}
+`,
+		},
 		func(runner tflint.Runner, rule tflint.Rule) error {
 			if err := checkVariables(runner, rule); err != nil {
 				return err
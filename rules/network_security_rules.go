@@ -0,0 +1,207 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const openSecurityRuleMessageTemplate = "Security rule %s allows inbound traffic from %s to sensitive ports %s"
+
+var defaultSensitivePorts = []int{22, 3389, 1433, 5432, 6379}
+
+// openSources are source address prefixes matching any address.
+var openSources = []string{"*", "0.0.0.0/0", "any", "internet"}
+
+type networkSecurityRulesRuleConfig struct {
+	Ports []int `hcl:"ports,optional"`
+}
+
+func NewNetworkSecurityRulesRule() *Rule {
+	return NewRule(
+		"network_security_rules",
+		Documentation{
+			Description: "Check that network security rules do not allow inbound traffic " +
+				"from `*`, `Internet` or `0.0.0.0/0` to sensitive ports: SSH, RDP, SQL Server, PostgreSQL and Redis. " +
+				"Values referencing variables are evaluated with variable values and defaults",
+			Example: `resource "azurerm_network_security_rule" "ssh" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "*"
+  destination_port_range = "22"
+}
+`,
+			Config: `ports = [22, 3389, 1433, 5432, 6379, 27017]
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := networkSecurityRulesRuleConfig{Ports: defaultSensitivePorts}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				for _, block := range body.Blocks {
+					if block.Type != "resource" || len(block.Labels) != 2 {
+						continue
+					}
+					addr := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+
+					switch block.Labels[0] {
+					case "azurerm_network_security_rule":
+						if err := checkSecurityRule(
+							runner,
+							rule,
+							config.Ports,
+							fmt.Sprintf("\"%s\"", addr),
+							block,
+						); err != nil {
+							return err
+						}
+					case "azurerm_network_security_group":
+						for _, nested := range block.Body.Blocks {
+							if nested.Type != "security_rule" {
+								continue
+							}
+
+							name, ok, err := attributeString(runner, nested.Body, "name")
+							if err != nil {
+								return err
+							}
+							if !ok {
+								name = "<unknown>"
+							}
+
+							if err := checkSecurityRule(
+								runner,
+								rule,
+								config.Ports,
+								fmt.Sprintf("\"%s\" of \"%s\"", name, addr),
+								nested,
+							); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkSecurityRule(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	sensitivePorts []int,
+	name string,
+	block *hclsyntax.Block,
+) error {
+	for _, setting := range []struct{ name, value string }{
+		{name: "direction", value: "inbound"},
+		{name: "access", value: "allow"},
+	} {
+		value, ok, err := attributeString(runner, block.Body, setting.name)
+		if err != nil || !ok || !strings.EqualFold(value, setting.value) {
+			return err
+		}
+	}
+
+	sources, err := securityRuleValues(runner, block.Body, "source_address_prefix", "source_address_prefixes")
+	if err != nil {
+		return err
+	}
+	source := ""
+	for _, value := range sources {
+		if containsString(openSources, strings.ToLower(value)) {
+			source = value
+			break
+		}
+	}
+	if source == "" {
+		return nil
+	}
+
+	ranges, err := securityRuleValues(runner, block.Body, "destination_port_range", "destination_port_ranges")
+	if err != nil {
+		return err
+	}
+	sorted := append([]int{}, sensitivePorts...)
+	sort.Ints(sorted)
+
+	ports := []string{}
+	for _, port := range sorted {
+		for _, portRange := range ranges {
+			if portRangeContains(portRange, port) {
+				ports = append(ports, strconv.Itoa(port))
+				break
+			}
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return runner.EmitIssue(
+		rule,
+		fmt.Sprintf(openSecurityRuleMessageTemplate, name, source, strings.Join(ports, ", ")),
+		block.DefRange(),
+	)
+}
+
+// securityRuleValues returns known values of the argument set either with
+// the attribute holding a single value or the one holding a list.
+func securityRuleValues(runner tflint.Runner, body *hclsyntax.Body, single, list string) ([]string, error) {
+	values, _, err := attributeStringList(runner, body, list)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok, err := attributeString(runner, body, single)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		values = append([]string{value}, values...)
+	}
+
+	return values, nil
+}
+
+// portRangeContains reports whether the port range like "*", "22"
+// or "1000-2000" contains the port.
+func portRangeContains(portRange string, port int) bool {
+	portRange = strings.TrimSpace(portRange)
+	if portRange == "*" {
+		return true
+	}
+
+	bounds := strings.SplitN(portRange, "-", 2)
+	from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return false
+	}
+	to := from
+	if len(bounds) == 2 {
+		if to, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+			return false
+		}
+	}
+
+	return from <= port && port <= to
+}
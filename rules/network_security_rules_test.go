@@ -0,0 +1,186 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_NetworkSecurityRules(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `resource "azurerm_network_security_rule" "https" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "*"
+  destination_port_range = "443"
+}
+
+resource "azurerm_network_security_rule" "ssh_from_office" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "10.0.0.0/8"
+  destination_port_range = "22"
+}
+
+resource "azurerm_network_security_rule" "deny_rdp" {
+  direction              = "Inbound"
+  access                 = "Deny"
+  source_address_prefix  = "Internet"
+  destination_port_range = "3389"
+}
+
+resource "azurerm_network_security_rule" "outbound" {
+  direction              = "Outbound"
+  access                 = "Allow"
+  source_address_prefix  = "*"
+  destination_port_range = "*"
+}
+
+resource "azurerm_network_security_rule" "unknown" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = var.source
+  destination_port_range = "22"
+}
+
+variable "source" {}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "standalone and inline rules",
+			Content: map[string]string{
+				filename: `resource "azurerm_network_security_rule" "ssh" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "Internet"
+  destination_port_range = "22"
+}
+
+resource "azurerm_network_security_group" "db" {
+  security_rule {
+    name                    = "databases"
+    direction               = "inbound"
+    access                  = "allow"
+    source_address_prefixes = ["10.0.0.0/8", "0.0.0.0/0"]
+    destination_port_ranges = ["1000-2000", "5432", "6379"]
+  }
+
+  security_rule {
+    name                   = "web"
+    direction              = "Inbound"
+    access                 = "Allow"
+    source_address_prefix  = "*"
+    destination_port_range = "80"
+  }
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewNetworkSecurityRulesRule(),
+					Message: fmt.Sprintf(
+						openSecurityRuleMessageTemplate,
+						`"azurerm_network_security_rule.ssh"`,
+						"Internet",
+						"22",
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 47},
+					},
+				},
+				{
+					Rule: NewNetworkSecurityRulesRule(),
+					Message: fmt.Sprintf(
+						openSecurityRuleMessageTemplate,
+						`"databases" of "azurerm_network_security_group.db"`,
+						"0.0.0.0/0",
+						"1433, 5432, 6379",
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "variable defaults and configured ports",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_network_security_rules" {
+  enabled = true
+  ports   = [22, 27017]
+}
+`,
+				filename: `variable "allowed_sources" {
+  default = ["*"]
+}
+
+variable "port" {
+  default = 27017
+}
+
+resource "azurerm_network_security_rule" "mongo" {
+  direction               = "Inbound"
+  access                  = "Allow"
+  source_address_prefixes = var.allowed_sources
+  destination_port_range  = var.port
+}
+
+resource "azurerm_network_security_rule" "rdp" {
+  direction               = "Inbound"
+  access                  = "Allow"
+  source_address_prefixes = var.allowed_sources
+  destination_port_range  = "3389"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewNetworkSecurityRulesRule(),
+					Message: fmt.Sprintf(
+						openSecurityRuleMessageTemplate,
+						`"azurerm_network_security_rule.mongo"`,
+						"*",
+						"27017",
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 9, Column: 1},
+						End:      hcl.Pos{Line: 9, Column: 49},
+					},
+				},
+			},
+		},
+	}
+	rule := NewNetworkSecurityRulesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newEvalRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
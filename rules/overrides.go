@@ -0,0 +1,391 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// overrideBlock changes rules for files matching any of its glob patterns,
+// relative to the working directory. "**" matches any number of directories
+// and a pattern matching a directory applies to all files below it:
+//
+//	plugin "dodo" {
+//	  override {
+//	    files = ["legacy"]
+//
+//	    rule "dodo_comments" {
+//	      enabled = false
+//	    }
+//	  }
+//
+//	  override {
+//	    files = ["modules/shared/**/*.tf"]
+//
+//	    rule "dodo_line_length" {
+//	      max = 100
+//	    }
+//	  }
+//	}
+//
+// Later overrides take precedence over earlier ones.
+type overrideBlock struct {
+	Files []string            `hcl:"files"`
+	Rules []overrideRuleBlock `hcl:"rule,block"`
+}
+
+type overrideRuleBlock struct {
+	Name    string   `hcl:"name,label"`
+	Enabled *bool    `hcl:"enabled,optional"`
+	Body    hcl.Body `hcl:",remain"`
+}
+
+// hasOptions reports whether the block sets rule options besides enabled,
+// either attributes or nested blocks. Bodies of other syntaxes are
+// assumed to set options.
+func (b overrideRuleBlock) hasOptions() bool {
+	body, ok := b.Body.(*hclsyntax.Body)
+	if !ok {
+		return true
+	}
+	for name := range body.Attributes {
+		if name != "enabled" {
+			return true
+		}
+	}
+
+	return len(body.Blocks) != 0
+}
+
+// ruleOverride is an override of a single rule, options is nil
+// if the override sets no rule options.
+type ruleOverride struct {
+	patterns []string
+	enabled  *bool
+	options  hcl.Body
+}
+
+func (o ruleOverride) matches(filename string) bool {
+	name := RelativePath(filename)
+	for _, pattern := range o.patterns {
+		if matchPathPattern(pattern, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyOverrides assigns overrides to rules. It returns names of rules
+// which are enabled only for some paths.
+func (r *RuleSet) applyOverrides(overrides []overrideBlock) (map[string]bool, error) {
+	rules := map[string]*Rule{}
+	for _, rule := range r.rules {
+		rule.overrides = nil
+		rules[rule.Name()] = rule
+	}
+
+	enabledForPaths := map[string]bool{}
+	for _, override := range overrides {
+		if len(override.Files) == 0 {
+			return nil, fmt.Errorf("override must match at least one file pattern")
+		}
+		for _, pattern := range override.Files {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid override file pattern \"%s\": %w", pattern, err)
+			}
+		}
+
+		for _, block := range override.Rules {
+			rule, ok := rules[block.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown rule \"%s\" in override", block.Name)
+			}
+
+			ruleOverride := ruleOverride{patterns: override.Files, enabled: block.Enabled}
+			if block.hasOptions() {
+				ruleOverride.options = block.Body
+			}
+			rule.overrides = append(rule.overrides, ruleOverride)
+
+			if block.Enabled != nil && *block.Enabled {
+				enabledForPaths[rule.Name()] = true
+			}
+		}
+	}
+
+	return enabledForPaths, nil
+}
+
+// overrideSettings are rule settings of a file after applying overrides.
+type overrideSettings struct {
+	enabled bool
+	options []hcl.Body
+	// applied are indexes of overrides setting options.
+	applied []int
+}
+
+func (s overrideSettings) key() string {
+	return fmt.Sprintf("%t %v", s.enabled, s.applied)
+}
+
+func (rule *Rule) overrideSettings(filename string) overrideSettings {
+	settings := overrideSettings{enabled: !rule.pathsOnly}
+	if filename == "" {
+		return settings
+	}
+
+	for i, override := range rule.overrides {
+		if !override.matches(filename) {
+			continue
+		}
+		if override.enabled != nil {
+			settings.enabled = *override.enabled
+		}
+		if override.options != nil {
+			settings.options = append(settings.options, override.options)
+			settings.applied = append(settings.applied, i)
+		}
+	}
+
+	return settings
+}
+
+// checkWithOverrides runs the rule once for every distinct settings of the
+// module files, reporting only issues of the files with those settings.
+func (rule *Rule) checkWithOverrides(runner tflint.Runner) error {
+	files, err := runner.Files()
+	if err != nil {
+		return err
+	}
+
+	// Issues of unknown files, e.g. the module itself, use default settings.
+	defaults := rule.overrideSettings("")
+	groups := map[string]overrideSettings{defaults.key(): defaults}
+	assigned := map[string]string{}
+	for filename := range files {
+		settings := rule.overrideSettings(filename)
+		groups[settings.key()] = settings
+		assigned[filename] = settings.key()
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		settings := groups[key]
+		if !settings.enabled {
+			continue
+		}
+
+		overridden := &overrideRunner{
+			Runner:   runner,
+			rule:     rule,
+			options:  settings.options,
+			assigned: assigned,
+			key:      key,
+			fallback: key == defaults.key(),
+		}
+		if err := rule.checkFunc(overridden, rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// overrideRunner decodes overridden rule options and drops issues of files
+// checked with other settings.
+type overrideRunner struct {
+	tflint.Runner
+
+	rule     *Rule
+	options  []hcl.Body
+	assigned map[string]string
+	key      string
+	fallback bool
+}
+
+var (
+	_ Fixer            = &overrideRunner{}
+	_ FileIssueEmitter = &overrideRunner{}
+)
+
+func (r *overrideRunner) reports(filename string) bool {
+	key, ok := r.assigned[filename]
+	if !ok {
+		return r.fallback
+	}
+
+	return key == r.key
+}
+
+func (r *overrideRunner) DecodeRuleConfig(name string, ret interface{}) error {
+	if err := r.Runner.DecodeRuleConfig(name, ret); err != nil {
+		return err
+	}
+	if name != r.rule.Name() {
+		return nil
+	}
+
+	for _, options := range r.options {
+		if diags := mergeRuleConfig(options, ret); diags.HasErrors() {
+			return diags
+		}
+	}
+
+	return nil
+}
+
+// mergeRuleConfig decodes overridden options into a copy of the rule config
+// and sets only arguments declared by the body, so other options keep values
+// of the rule config and earlier overrides. Nested blocks are appended to
+// blocks of the same type.
+func mergeRuleConfig(body hcl.Body, ret interface{}) hcl.Diagnostics {
+	target := reflect.ValueOf(ret)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return gohcl.DecodeBody(body, nil, ret)
+	}
+	target = target.Elem()
+
+	override := reflect.New(target.Type())
+	if diags := gohcl.DecodeBody(body, nil, override.Interface()); diags.HasErrors() {
+		return diags
+	}
+
+	schema, _ := gohcl.ImpliedBodySchema(ret)
+	content, _, diags := body.PartialContent(schema)
+	if diags.HasErrors() {
+		return diags
+	}
+	declared := map[string]bool{}
+	for name := range content.Attributes {
+		declared[name] = true
+	}
+	for _, block := range content.Blocks {
+		declared[block.Type] = true
+	}
+
+	for i := 0; i < target.NumField(); i++ {
+		name, kind := hclFieldTag(target.Type().Field(i))
+		if !declared[name] {
+			continue
+		}
+
+		field, value := target.Field(i), override.Elem().Field(i)
+		if kind == "block" && field.Kind() == reflect.Slice {
+			field.Set(reflect.AppendSlice(field, value))
+			continue
+		}
+		field.Set(value)
+	}
+
+	return nil
+}
+
+// hclFieldTag returns the name and the kind of the field from its hcl tag.
+func hclFieldTag(field reflect.StructField) (string, string) {
+	parts := strings.SplitN(field.Tag.Get("hcl"), ",", 2)
+	if len(parts) == 1 {
+		return parts[0], "attr"
+	}
+
+	return parts[0], parts[1]
+}
+
+func (r *overrideRunner) EmitIssueOnExpr(rule tflint.Rule, message string, expr hcl.Expression) error {
+	if !r.reports(expr.Range().Filename) {
+		return nil
+	}
+
+	return r.Runner.EmitIssueOnExpr(rule, message, expr)
+}
+
+func (r *overrideRunner) EmitIssue(rule tflint.Rule, message string, location hcl.Range) error {
+	if !r.reports(location.Filename) {
+		return nil
+	}
+
+	return r.Runner.EmitIssue(rule, message, location)
+}
+
+func (r *overrideRunner) EmitIssueWithFix(rule tflint.Rule, message string, location hcl.Range, fix Fix) error {
+	if !r.reports(location.Filename) {
+		return nil
+	}
+
+	return emitIssueWithFix(r.Runner, rule, message, location, fix)
+}
+
+func (r *overrideRunner) EmitFileIssue(rule tflint.Rule, message string, location hcl.Range) error {
+	if !r.reports(location.Filename) {
+		return nil
+	}
+
+	return emitFileIssue(r.Runner, rule, message, location)
+}
+
+// RelativePath returns the slash-separated filename relative to the working
+// directory, the one override patterns and diff paths are relative to.
+func RelativePath(filename string) string {
+	if filepath.IsAbs(filename) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, filename); err == nil {
+				filename = rel
+			}
+		}
+	}
+
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+// matchPathPattern reports whether the pattern matches the path or
+// any of its parent directories.
+func matchPathPattern(pattern, name string) bool {
+	patternSegments := strings.Split(path.Clean(pattern), "/")
+	nameSegments := strings.Split(name, "/")
+	for i := len(nameSegments); i > 0; i-- {
+		if matchSegments(patternSegments, nameSegments[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], name[1:])
+}
@@ -0,0 +1,258 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Overrides(t *testing.T) {
+	t.Parallel()
+
+	content := `# comment
+locals {
+  name = "0123456789012345678901234567890123456789"
+}
+`
+	files := map[string]string{
+		"main.tf":                        content,
+		"legacy/main.tf":                 content,
+		"modules/shared/network/main.tf": content,
+		"legacy/resources.tf": `resource "azurerm_old" "test" {
+  name = "example"
+}
+`,
+	}
+
+	cases := []struct {
+		Name     string
+		Body     string
+		Rules    map[string]*tflint.RuleConfig
+		Expected []string
+		Error    bool
+	}{
+		{
+			Name: "no overrides",
+			Body: `preset = "formatting-only"`,
+			Expected: []string{
+				"dodo_comments legacy/main.tf:1",
+				"dodo_comments main.tf:1",
+				"dodo_comments modules/shared/network/main.tf:1",
+			},
+		},
+		{
+			Name: "rule disabled for directory",
+			Body: `
+preset = "formatting-only"
+
+override {
+  files = ["legacy"]
+
+  rule "dodo_comments" {
+    enabled = false
+  }
+}`,
+			Expected: []string{
+				"dodo_comments main.tf:1",
+				"dodo_comments modules/shared/network/main.tf:1",
+			},
+		},
+		{
+			Name: "rule options overridden by later override",
+			Body: `
+preset = "formatting-only"
+
+override {
+  files = ["**/*.tf"]
+
+  rule "dodo_comments" {
+    enabled = false
+  }
+  rule "dodo_line_length" {
+    max = 40
+  }
+}
+
+override {
+  files = ["modules/shared/**"]
+
+  rule "dodo_line_length" {
+    max = 100
+  }
+}`,
+			Expected: []string{
+				"dodo_line_length legacy/main.tf:3",
+				"dodo_line_length main.tf:3",
+			},
+		},
+		{
+			Name: "rule enabled only for paths",
+			Body: `
+preset = "formatting-only"
+
+override {
+  files = ["modules/shared/*/main.tf"]
+
+  rule "dodo_comments" {
+    enabled = true
+  }
+}`,
+			Rules: map[string]*tflint.RuleConfig{
+				"dodo_comments": {Name: "dodo_comments", Enabled: false},
+			},
+			Expected: []string{
+				"dodo_comments modules/shared/network/main.tf:1",
+			},
+		},
+		{
+			Name: "rule block options overridden",
+			Body: `
+preset = "formatting-only"
+
+override {
+  files = ["legacy"]
+
+  rule "dodo_comments" {
+    enabled = false
+  }
+  rule "dodo_deprecations" {
+    enabled = true
+
+    resource "azurerm_old" {
+      forbidden = true
+    }
+  }
+}`,
+			Expected: []string{
+				"dodo_comments main.tf:1",
+				"dodo_comments modules/shared/network/main.tf:1",
+				"dodo_deprecations legacy/resources.tf:1",
+			},
+		},
+		{
+			Name: "unknown rule",
+			Body: `
+override {
+  files = ["legacy"]
+
+  rule "dodo_unknown" {
+    enabled = false
+  }
+}`,
+			Error: true,
+		},
+		{
+			Name: "invalid pattern",
+			Body: `
+override {
+  files = ["legacy/["]
+}`,
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			file, diags := hclsyntax.ParseConfig([]byte(tc.Body), "plugin.hcl", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags)
+
+			ruleSet := NewRuleSet("dev")
+			err := ruleSet.ApplyConfig(&tflint.Config{Rules: tc.Rules, Body: file.Body})
+			if tc.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			runner := helper.TestRunner(t, files)
+			require.NoError(t, ruleSet.Check(runner))
+
+			issues := []string{}
+			for _, issue := range runner.Issues {
+				issues = append(issues, fmt.Sprintf("%s %s:%d", issue.Rule.Name(), issue.Range.Filename, issue.Range.Start.Line))
+			}
+			require.ElementsMatch(t, tc.Expected, issues)
+		})
+	}
+}
+
+func Test_MatchPathPattern(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Pattern string
+		Name    string
+		Matches bool
+	}{
+		{Pattern: "legacy", Name: "legacy/main.tf", Matches: true},
+		{Pattern: "legacy/", Name: "legacy/app/main.tf", Matches: true},
+		{Pattern: "legacy", Name: "modules/legacy/main.tf", Matches: false},
+		{Pattern: "**/legacy", Name: "modules/legacy/main.tf", Matches: true},
+		{Pattern: "*.tf", Name: "main.tf", Matches: true},
+		{Pattern: "*.tf", Name: "app/main.tf", Matches: false},
+		{Pattern: "**/*.tf", Name: "main.tf", Matches: true},
+		{Pattern: "modules/**/variables.tf", Name: "modules/a/b/variables.tf", Matches: true},
+		{Pattern: "modules/**/variables.tf", Name: "modules/a/b/main.tf", Matches: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Pattern+" "+tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.Matches, matchPathPattern(tc.Pattern, tc.Name))
+		})
+	}
+}
+
+func Test_MergeRuleConfig(t *testing.T) {
+	t.Parallel()
+
+	type block struct {
+		Name  string `hcl:"name,label"`
+		Value string `hcl:"value"`
+	}
+	type config struct {
+		Max    int      `hcl:"max,optional"`
+		Names  []string `hcl:"names,optional"`
+		Blocks []block  `hcl:"block,block"`
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(`
+enabled = true
+max     = 100
+
+block "second" {
+  value = "2"
+}
+`), "plugin.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags)
+
+	var rule struct {
+		Enabled bool     `hcl:"enabled"`
+		Body    hcl.Body `hcl:",remain"`
+	}
+	require.False(t, gohcl.DecodeBody(file.Body, nil, &rule).HasErrors())
+
+	cfg := config{
+		Max:    120,
+		Names:  []string{"name"},
+		Blocks: []block{{Name: "first", Value: "1"}},
+	}
+	diags = mergeRuleConfig(rule.Body, &cfg)
+	require.False(t, diags.HasErrors(), diags)
+	require.Equal(t, config{
+		Max:    100,
+		Names:  []string{"name"},
+		Blocks: []block{{Name: "first", Value: "1"}, {Name: "second", Value: "2"}},
+	}, cfg)
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	providerInModuleMessageTemplate = "Provider \"%s\" should not be configured in reusable module, " +
+		"it is inherited from the caller"
+	aliasedProviderInModuleMessageTemplate = "Provider \"%s.%s\" should not be configured in reusable module, " +
+		"declare it in configuration_aliases of required_providers instead"
+	undeclaredProviderAliasMessageTemplate = "Provider \"%s.%s\" should be declared " +
+		"in configuration_aliases of required_providers"
+)
+
+func NewProviderInModuleRule() *Rule {
+	return NewRule(
+		"provider_in_module",
+		Documentation{
+			Description: "Check that reusable modules (placed into `modules/` directory or without backend) " +
+				"do not configure providers and declare aliased providers they use in `configuration_aliases` instead",
+			Example: `// modules/network/main.tf
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "hub" {
+  provider = azurerm.hub
+}
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			root, err := isRootModule(runner)
+			if err != nil || root {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+			settings, err := getTerraformSettings(runner)
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				for _, block := range body.Blocks {
+					if block.Type == "provider" {
+						if err := runner.EmitIssue(
+							rule,
+							providerInModuleMessage(block),
+							block.DefRange(),
+						); err != nil {
+							return err
+						}
+
+						continue
+					}
+
+					for _, expr := range providerReferences(block) {
+						if err := checkProviderAlias(runner, rule, settings, expr); err != nil {
+							return err
+						}
+					}
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func providerInModuleMessage(block *hclsyntax.Block) string {
+	name := block.Labels[0]
+	if attr, ok := block.Body.Attributes["alias"]; ok {
+		var alias string
+		if keyword := hcl.ExprAsKeyword(attr.Expr); keyword != "" {
+			alias = keyword
+		} else if literal, ok := quotedLiteral(attr.Expr); ok {
+			alias = literal
+		}
+
+		if alias != "" {
+			return fmt.Sprintf(aliasedProviderInModuleMessageTemplate, name, alias)
+		}
+	}
+
+	return fmt.Sprintf(providerInModuleMessageTemplate, name)
+}
+
+// providerReferences returns expressions referencing providers in the
+// provider meta-argument of resources and data sources and in the providers
+// map of module calls.
+func providerReferences(block *hclsyntax.Block) []hcl.Expression {
+	switch block.Type {
+	case "resource", "data":
+		if attr, ok := block.Body.Attributes["provider"]; ok {
+			return []hcl.Expression{attr.Expr}
+		}
+	case "module":
+		attr, ok := block.Body.Attributes["providers"]
+		if !ok {
+			return nil
+		}
+		pairs, diags := hcl.ExprMap(attr.Expr)
+		if diags.HasErrors() {
+			return nil
+		}
+
+		exprs := make([]hcl.Expression, 0, len(pairs))
+		for _, pair := range pairs {
+			exprs = append(exprs, pair.Value)
+		}
+
+		return exprs
+	}
+
+	return nil
+}
+
+// checkProviderAlias reports a reference to an aliased provider like
+// azurerm.hub unless the alias is declared in configuration_aliases.
+func checkProviderAlias(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	settings *terraformSettings,
+	expr hcl.Expression,
+) error {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() || len(traversal) != 2 {
+		return nil
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return nil
+	}
+
+	name := traversal.RootName()
+	if provider, ok := settings.providers[name]; ok && containsString(provider.aliases, attr.Name) {
+		return nil
+	}
+
+	return runner.EmitIssueOnExpr(
+		rule,
+		fmt.Sprintf(undeclaredProviderAliasMessageTemplate, name, attr.Name),
+		expr,
+	)
+}
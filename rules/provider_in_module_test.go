@@ -0,0 +1,168 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_ProviderInModule(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues in root module",
+			Content: map[string]string{
+				filename: `terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {
+  features {}
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "provider in module without backend",
+			Content: map[string]string{
+				filename: `provider "azurerm" {
+  features {}
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewProviderInModuleRule(),
+					Message: fmt.Sprintf(providerInModuleMessageTemplate, "azurerm"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "aliased provider in modules directory",
+			Content: map[string]string{
+				"modules/network/main.tf": `terraform {
+  backend "azurerm" {}
+}
+
+provider "azurerm" {
+  alias = "hub"
+
+  features {}
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewProviderInModuleRule(),
+					Message: fmt.Sprintf(aliasedProviderInModuleMessageTemplate, "azurerm", "hub"),
+					Range: hcl.Range{
+						Filename: "modules/network/main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "declared configuration aliases",
+			Content: map[string]string{
+				filename: `terraform {
+  required_providers {
+    azurerm = {
+      source                = "hashicorp/azurerm"
+      configuration_aliases = [azurerm.hub]
+    }
+  }
+}
+
+resource "azurerm_resource_group" "hub" {
+  provider = azurerm.hub
+}
+
+module "network" {
+  source = "./network"
+
+  providers = {
+    azurerm = azurerm.hub
+  }
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "undeclared configuration aliases",
+			Content: map[string]string{
+				filename: `terraform {
+  required_providers {
+    azurerm = {
+      source                = "hashicorp/azurerm"
+      configuration_aliases = [azurerm.hub]
+    }
+  }
+}
+
+data "azurerm_client_config" "spoke" {
+  provider = azurerm.spoke
+}
+
+module "network" {
+  source = "./network"
+
+  providers = {
+    azurerm = azurerm
+    random  = random.main
+  }
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewProviderInModuleRule(),
+					Message: fmt.Sprintf(undeclaredProviderAliasMessageTemplate, "azurerm", "spoke"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 11, Column: 14},
+						End:      hcl.Pos{Line: 11, Column: 27},
+					},
+				},
+				{
+					Rule:    NewProviderInModuleRule(),
+					Message: fmt.Sprintf(undeclaredProviderAliasMessageTemplate, "random", "main"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 19, Column: 15},
+						End:      hcl.Pos{Line: 19, Column: 26},
+					},
+				},
+			},
+		},
+	}
+	rule := NewProviderInModuleRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	privilegedRoleAssignmentMessageTemplate = "Role assignment \"%s\" grants \"%s\" at %s scope"
+	roleDefinitionIDLiteralMessageTemplate  = "Role assignment \"%s\" should set role_definition_name " +
+		"instead of role_definition_id literal"
+	principalIDLiteralMessageTemplate = "Role assignment \"%s\" should take principal_id " +
+		"from a reference instead of a literal GUID"
+)
+
+const (
+	subscriptionScope    = "subscription"
+	managementGroupScope = "management group"
+)
+
+var defaultPrivilegedRoles = []string{"Owner", "User Access Administrator", "Contributor"}
+
+const guidPattern = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+
+var (
+	subscriptionScopeRegexp    = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/?$`)
+	managementGroupScopeRegexp = regexp.MustCompile(`(?i)^/providers/microsoft\.management/managementgroups/[^/]+/?$`)
+	roleDefinitionIDRegexp     = regexp.MustCompile(`(?i)(^|/)roledefinitions/` + guidPattern + `/?$`)
+	guidRegexp                 = regexp.MustCompile(`^` + guidPattern + `$`)
+)
+
+// scopeResourceTypes are resources and data sources whose ids are broad scopes.
+// The id of the azurerm_subscription resource is the subscription alias id
+// rather than the subscription scope, so only the data source counts.
+var scopeResourceTypes = map[string]string{
+	"data.azurerm_subscription":     subscriptionScope,
+	"azurerm_management_group":      managementGroupScope,
+	"data.azurerm_management_group": managementGroupScope,
+}
+
+type roleAssignmentsRuleConfig struct {
+	PrivilegedRoles []string `hcl:"privileged_roles,optional"`
+	AllowedModules  []string `hcl:"allowed_modules,optional"`
+}
+
+func NewRoleAssignmentsRule() *Rule {
+	return NewRule(
+		"role_assignments",
+		Documentation{
+			Description: "Check that role assignments do not grant `Owner`, `User Access Administrator` or `Contributor` " +
+				"at subscription or management group scope, set roles by names instead of `role_definition_id` literals " +
+				"and do not hardcode `principal_id` GUIDs. Modules matching `allowed_modules` path patterns are not checked",
+			Example: `resource "azurerm_role_assignment" "example" {
+  scope                = data.azurerm_subscription.current.id
+  role_definition_name = "Owner"
+  principal_id         = "00000000-0000-0000-0000-000000000000"
+}
+`,
+			Config: `privileged_roles = ["Owner", "User Access Administrator", "Contributor"]
+allowed_modules  = ["modules/platform/**"]
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := roleAssignmentsRuleConfig{PrivilegedRoles: defaultPrivilegedRoles}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range SortedFilenames(files) {
+				if isAllowedModule(config.AllowedModules, filename) {
+					continue
+				}
+
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				for _, block := range body.Blocks {
+					if block.Type != "resource" || len(block.Labels) != 2 || block.Labels[0] != "azurerm_role_assignment" {
+						continue
+					}
+
+					if err := checkRoleAssignment(runner, rule, config, block); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkRoleAssignment(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	config roleAssignmentsRuleConfig,
+	block *hclsyntax.Block,
+) error {
+	addr := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+
+	role, ok, err := privilegedRole(runner, block.Body, config.PrivilegedRoles)
+	if err != nil {
+		return err
+	}
+	if attr, exists := block.Body.Attributes["scope"]; ok && exists {
+		scope, err := scopeKind(runner, attr.Expr)
+		if err != nil {
+			return err
+		}
+		if scope != "" {
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(privilegedRoleAssignmentMessageTemplate, addr, role, scope),
+				block.DefRange(),
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	if attr, ok := block.Body.Attributes["role_definition_id"]; ok && isRoleDefinitionIDLiteral(attr.Expr) {
+		if err := runner.EmitIssueOnExpr(
+			rule,
+			fmt.Sprintf(roleDefinitionIDLiteralMessageTemplate, addr),
+			attr.Expr,
+		); err != nil {
+			return err
+		}
+	}
+
+	if attr, ok := block.Body.Attributes["principal_id"]; ok {
+		if value, ok := quotedLiteral(attr.Expr); ok && guidRegexp.MatchString(value) {
+			return runner.EmitIssueOnExpr(
+				rule,
+				fmt.Sprintf(principalIDLiteralMessageTemplate, addr),
+				attr.Expr,
+			)
+		}
+	}
+
+	return nil
+}
+
+// privilegedRole returns the role name if it is one of the privileged roles.
+func privilegedRole(runner tflint.Runner, body *hclsyntax.Body, privilegedRoles []string) (string, bool, error) {
+	name, ok, err := attributeString(runner, body, "role_definition_name")
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	for _, role := range privilegedRoles {
+		if strings.EqualFold(role, name) {
+			return role, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// scopeKind returns the kind of subscription or management group scope, or
+// empty string for narrower and unknown scopes. Scopes are recognized by
+// their ids or references to subscription and management group ids.
+func scopeKind(runner tflint.Runner, expr hclsyntax.Expression) (string, error) {
+	var id string
+	ok, err := evaluateExpr(runner, expr, &id, cty.String)
+	if err != nil || ok {
+		return scopeKindOfID(id), err
+	}
+
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return scopeKindOfTraversal(e.Traversal), nil
+	case *hclsyntax.TemplateExpr:
+		return scopeKindOfID(templateLiteral(e)), nil
+	}
+
+	return "", nil
+}
+
+// isRoleDefinitionIDLiteral reports whether the role definition id is
+// a literal or a template with a literal roleDefinitions/<GUID> segment,
+// e.g. with an interpolated subscription id.
+func isRoleDefinitionIDLiteral(expr hclsyntax.Expression) bool {
+	if len(expr.Variables()) == 0 {
+		return true
+	}
+
+	template, ok := expr.(*hclsyntax.TemplateExpr)
+
+	return ok && roleDefinitionIDRegexp.MatchString(templateLiteral(template))
+}
+
+// templateLiteral renders literal parts of the template with placeholders
+// standing for interpolated parts like subscription ids.
+func templateLiteral(template *hclsyntax.TemplateExpr) string {
+	var literal strings.Builder
+	for _, part := range template.Parts {
+		if value, ok := part.(*hclsyntax.LiteralValueExpr); ok && value.Val.Type() == cty.String {
+			literal.WriteString(value.Val.AsString())
+			continue
+		}
+		literal.WriteString("{}")
+	}
+
+	return literal.String()
+}
+
+func scopeKindOfID(id string) string {
+	switch {
+	case subscriptionScopeRegexp.MatchString(id):
+		return subscriptionScope
+	case managementGroupScopeRegexp.MatchString(id):
+		return managementGroupScope
+	default:
+		return ""
+	}
+}
+
+// scopeKindOfTraversal recognizes references like
+// data.azurerm_subscription.current.id.
+func scopeKindOfTraversal(traversal hcl.Traversal) string {
+	resourceType := traversal.RootName()
+	attrs := traversal.SimpleSplit().Rel
+	if resourceType == "data" && len(attrs) != 0 {
+		if attr, ok := attrs[0].(hcl.TraverseAttr); ok {
+			resourceType = "data." + attr.Name
+			attrs = attrs[1:]
+		}
+	}
+
+	scope, ok := scopeResourceTypes[resourceType]
+	if !ok || len(attrs) < 2 {
+		return ""
+	}
+	if attr, ok := attrs[len(attrs)-1].(hcl.TraverseAttr); !ok || attr.Name != "id" {
+		return ""
+	}
+
+	return scope
+}
+
+// isAllowedModule reports whether the file belongs to one of the modules
+// matching path patterns.
+func isAllowedModule(patterns []string, filename string) bool {
+	name := RelativePath(filename)
+	for _, pattern := range patterns {
+		if matchPathPattern(pattern, name) {
+			return true
+		}
+	}
+
+	return false
+}
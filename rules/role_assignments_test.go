@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_RoleAssignments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				filename: `resource "azurerm_role_assignment" "reader" {
+  scope                = data.azurerm_subscription.current.id
+  role_definition_name = "Reader"
+  principal_id         = azurerm_user_assigned_identity.app.principal_id
+}
+
+resource "azurerm_role_assignment" "contributor" {
+  scope                = azurerm_resource_group.app.id
+  role_definition_name = "Contributor"
+  principal_id         = var.principal_id
+}
+
+resource "azurerm_role_assignment" "custom" {
+  scope              = "/subscriptions/${var.subscription_id}/resourceGroups/app"
+  role_definition_id = azurerm_role_definition.custom.role_definition_resource_id
+  principal_id       = var.principal_id
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "privileged roles at broad scopes",
+			Content: map[string]string{
+				filename: `variable "subscription_id" {}
+
+variable "role" {
+  default = "user access administrator"
+}
+
+resource "azurerm_role_assignment" "owner" {
+  scope                = data.azurerm_subscription.current.id
+  role_definition_name = "Owner"
+  principal_id         = var.principal_id
+}
+
+resource "azurerm_role_assignment" "uaa" {
+  scope                = "/subscriptions/${var.subscription_id}"
+  role_definition_name = var.role
+  principal_id         = var.principal_id
+}
+
+resource "azurerm_role_assignment" "contributor" {
+  scope                = "/providers/Microsoft.Management/managementGroups/dodo"
+  role_definition_name = "Contributor"
+  principal_id         = var.principal_id
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(
+						privilegedRoleAssignmentMessageTemplate,
+						"azurerm_role_assignment.owner",
+						"Owner",
+						subscriptionScope,
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 7, Column: 1},
+						End:      hcl.Pos{Line: 7, Column: 43},
+					},
+				},
+				{
+					Rule: NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(
+						privilegedRoleAssignmentMessageTemplate,
+						"azurerm_role_assignment.uaa",
+						"User Access Administrator",
+						subscriptionScope,
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 13, Column: 1},
+						End:      hcl.Pos{Line: 13, Column: 41},
+					},
+				},
+				{
+					Rule: NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(
+						privilegedRoleAssignmentMessageTemplate,
+						"azurerm_role_assignment.contributor",
+						"Contributor",
+						managementGroupScope,
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 19, Column: 1},
+						End:      hcl.Pos{Line: 19, Column: 49},
+					},
+				},
+			},
+		},
+		{
+			Name: "literal role definition and principal ids",
+			Content: map[string]string{
+				filename: `resource "azurerm_role_assignment" "test" {
+  scope              = azurerm_resource_group.app.id
+  role_definition_id = "/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7"
+  principal_id       = "00000000-0000-0000-0000-000000000000"
+}
+
+resource "azurerm_role_assignment" "template" {
+  scope              = azurerm_resource_group.app.id
+  role_definition_id = "/subscriptions/${var.subscription_id}/providers/Microsoft.Authorization/roleDefinitions/` +
+					`acdd72a7-3385-48ef-bd42-f606fba81ae7"
+  principal_id       = azurerm_user_assigned_identity.app.principal_id
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(roleDefinitionIDLiteralMessageTemplate, "azurerm_role_assignment.test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 3, Column: 24},
+						End:      hcl.Pos{Line: 3, Column: 113},
+					},
+				},
+				{
+					Rule:    NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(principalIDLiteralMessageTemplate, "azurerm_role_assignment.test"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 4, Column: 24},
+						End:      hcl.Pos{Line: 4, Column: 62},
+					},
+				},
+				{
+					Rule:    NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(roleDefinitionIDLiteralMessageTemplate, "azurerm_role_assignment.template"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 9, Column: 24},
+						End:      hcl.Pos{Line: 9, Column: 150},
+					},
+				},
+			},
+		},
+		{
+			Name: "subscription alias id of the managed resource",
+			Content: map[string]string{
+				filename: `resource "azurerm_role_assignment" "owner" {
+  scope                = azurerm_subscription.alias.id
+  role_definition_name = "Owner"
+  principal_id         = azuread_group.admins.object_id
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "allowed modules and configured roles",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_role_assignments" {
+  enabled          = true
+  privileged_roles = ["Key Vault Administrator"]
+  allowed_modules  = ["modules/platform"]
+}
+`,
+				"modules/platform/main.tf": `resource "azurerm_role_assignment" "owner" {
+  scope                = data.azurerm_subscription.current.id
+  role_definition_name = "Owner"
+  principal_id         = "00000000-0000-0000-0000-000000000000"
+}
+`,
+				filename: `resource "azurerm_role_assignment" "owner" {
+  scope                = data.azurerm_subscription.current.id
+  role_definition_name = "Owner"
+  principal_id         = var.principal_id
+}
+
+resource "azurerm_role_assignment" "key_vault" {
+  scope                = data.azurerm_subscription.current.id
+  role_definition_name = "Key Vault Administrator"
+  principal_id         = var.principal_id
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewRoleAssignmentsRule(),
+					Message: fmt.Sprintf(
+						privilegedRoleAssignmentMessageTemplate,
+						"azurerm_role_assignment.key_vault",
+						"Key Vault Administrator",
+						subscriptionScope,
+					),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 7, Column: 1},
+						End:      hcl.Pos{Line: 7, Column: 47},
+					},
+				},
+			},
+		},
+	}
+	rule := NewRoleAssignmentsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newEvalRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -2,25 +2,51 @@ package rules
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-const rulePrefix = "dodo"
+const (
+	rulePrefix = "dodo"
+	modulesDir = "modules"
+)
 
 type Rule struct {
 	name      string
+	enabled   bool
+	severity  string
+	link      string
+	doc       Documentation
 	checkFunc func(tflint.Runner, tflint.Rule) error
+
+	overrides []ruleOverride
+	// pathsOnly rules are enabled only by overrides of some paths.
+	pathsOnly bool
+}
+
+// Documentation describes the rule in generated docs.
+// Example is a configuration violating the rule and Config is
+// an example of rule options, if the rule has any.
+type Documentation struct {
+	Description string
+	Example     string
+	Config      string
 }
 
 var _ tflint.Rule = &Rule{}
 
 func NewRule(
 	name string,
+	doc Documentation,
 	checkFunc func(tflint.Runner, tflint.Rule) error,
 ) *Rule {
 	return &Rule{
 		name:      fmt.Sprintf("%s_%s", rulePrefix, name),
+		enabled:   true,
+		severity:  tflint.ERROR,
+		doc:       doc,
 		checkFunc: checkFunc,
 	}
 }
@@ -30,15 +56,19 @@ func (rule *Rule) Name() string {
 }
 
 func (rule *Rule) Enabled() bool {
-	return true
+	return rule.enabled
 }
 
 func (rule *Rule) Severity() string {
-	return tflint.ERROR
+	return rule.severity
 }
 
 func (rule *Rule) Link() string {
-	return ""
+	return rule.link
+}
+
+func (rule *Rule) Documentation() Documentation {
+	return rule.doc
 }
 
 func (rule *Rule) Check(runner tflint.Runner) error {
@@ -52,5 +82,45 @@ func (rule *Rule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	if len(rule.overrides) != 0 {
+		return rule.checkWithOverrides(runner)
+	}
+
 	return rule.checkFunc(runner, rule)
 }
+
+// isRootModule reports whether the module is a root one, i.e. it has its own
+// backend configured and it is not placed into "modules" directory. Root
+// modules using the local backend without a backend block are treated as
+// reusable ones, since a module alone does not tell how it is used.
+func isRootModule(runner tflint.Runner) (bool, error) {
+	backend, err := runner.Backend()
+	if err != nil {
+		return false, err
+	}
+	if backend == nil {
+		return false, nil
+	}
+
+	files, err := runner.Files()
+	if err != nil {
+		return false, err
+	}
+	for filename := range files {
+		if isInModulesDir(filename) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func isInModulesDir(filename string) bool {
+	for _, segment := range strings.Split(filepath.ToSlash(filepath.Dir(filename)), "/") {
+		if segment == modulesDir {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,3 +1,220 @@
 package rules
 
-const filename = "resource.tf"
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+const (
+	filename             = "resource.tf"
+	tflintConfigFilename = ".tflint.hcl"
+)
+
+// fixRunner is a test runner which collects autofixes along with issues.
+type fixRunner struct {
+	*helper.Runner
+	Fixes []Fix
+
+	files map[string]*hcl.File
+}
+
+var _ Fixer = &fixRunner{}
+
+func newFixRunner(t *testing.T, files map[string]string) *fixRunner {
+	t.Helper()
+
+	return &fixRunner{Runner: helper.TestRunner(t, files)}
+}
+
+// newSyntaxRunner returns a runner serving files only through the Files method.
+// It is used for configurations which helper.TestRunner is unable to decode,
+// e.g. variables with type constraints.
+func newSyntaxRunner(t *testing.T, files map[string]string) *fixRunner {
+	t.Helper()
+
+	config := map[string]string{}
+	if src, ok := files[tflintConfigFilename]; ok {
+		config[tflintConfigFilename] = src
+	}
+
+	runner := &fixRunner{
+		Runner: helper.TestRunner(t, config),
+		files:  map[string]*hcl.File{},
+	}
+	parser := hclparse.NewParser()
+	for name, src := range files {
+		if name == tflintConfigFilename {
+			continue
+		}
+
+		file, diags := parser.ParseHCL([]byte(src), name)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		runner.files[name] = file
+	}
+
+	return runner
+}
+
+func (r *fixRunner) Files() (map[string]*hcl.File, error) {
+	if r.files == nil {
+		return r.Runner.Files()
+	}
+
+	return r.files, nil
+}
+
+func (r *fixRunner) File(name string) (*hcl.File, error) {
+	if r.files == nil {
+		return r.Runner.File(name)
+	}
+
+	return r.files[name], nil
+}
+
+func (r *fixRunner) EmitIssueWithFix(rule tflint.Rule, message string, location hcl.Range, fix Fix) error {
+	r.Fixes = append(r.Fixes, fix)
+
+	return r.EmitIssue(rule, message, location)
+}
+
+// evalRunner is a test runner evaluating expressions like TFLint does:
+// variables without defaults are unknown, local values are evaluated and
+// EnsureNoError skips warnings. helper.TestRunner fails on both instead.
+type evalRunner struct {
+	*helper.Runner
+}
+
+func newEvalRunner(t *testing.T, files map[string]string) *evalRunner {
+	t.Helper()
+
+	runner := &evalRunner{Runner: helper.TestRunner(t, files)}
+	decodeLocals(t, runner)
+
+	return runner
+}
+
+func (r *evalRunner) EvaluateExpr(expr hcl.Expression, ret interface{}, wantType *cty.Type) error {
+	cfg, err := r.Config()
+	if err != nil {
+		return err
+	}
+
+	for _, traversal := range expr.Variables() {
+		if !containsString(EvaluableRoots, traversal.RootName()) {
+			return tflint.Error{
+				Code:    tflint.UnevaluableError,
+				Level:   tflint.WarningLevel,
+				Message: "Unevaluable expression found in " + expr.Range().String(),
+			}
+		}
+	}
+
+	val, diags := expr.Value(EvalContext(cfg.Module))
+	if diags.HasErrors() {
+		return diags
+	}
+	if !val.IsWhollyKnown() {
+		return tflint.Error{
+			Code:    tflint.UnknownValueError,
+			Level:   tflint.WarningLevel,
+			Message: "Unknown value found in " + expr.Range().String(),
+		}
+	}
+	if val.IsNull() {
+		return tflint.Error{
+			Code:    tflint.NullValueError,
+			Level:   tflint.WarningLevel,
+			Message: "Null value found in " + expr.Range().String(),
+		}
+	}
+	if val, err = convert.Convert(val, *wantType); err != nil {
+		return err
+	}
+
+	return gocty.FromCtyValue(val, ret)
+}
+
+func (r *evalRunner) EnsureNoError(err error, proc func() error) error {
+	if appErr, ok := err.(tflint.Error); ok && appErr.Level == tflint.WarningLevel {
+		return nil
+	}
+
+	return r.Runner.EnsureNoError(err, proc)
+}
+
+// decodeLocals fills local values of the runner,
+// which are not decoded by helper.TestRunner.
+func decodeLocals(t *testing.T, runner tflint.Runner) {
+	t.Helper()
+
+	cfg, err := runner.Config()
+	require.NoError(t, err)
+	files, err := runner.Files()
+	require.NoError(t, err)
+
+	cfg.Module.Locals = map[string]*configs.Local{}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		require.True(t, ok)
+
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+
+			for name, attr := range block.Body.Attributes {
+				cfg.Module.Locals[name] = &configs.Local{
+					Name:      name,
+					Expr:      attr.Expr,
+					DeclRange: attr.Range(),
+				}
+			}
+		}
+	}
+}
+
+// decodeProviderConfigs fills provider configurations of the runner,
+// which are not decoded by helper.TestRunner.
+func decodeProviderConfigs(t *testing.T, runner tflint.Runner) {
+	t.Helper()
+
+	cfg, err := runner.Config()
+	require.NoError(t, err)
+	files, err := runner.Files()
+	require.NoError(t, err)
+
+	cfg.Module.ProviderConfigs = map[string]*configs.Provider{}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		require.True(t, ok)
+
+		for _, block := range body.Blocks {
+			if block.Type != "provider" {
+				continue
+			}
+
+			provider := &configs.Provider{
+				Name:      block.Labels[0],
+				NameRange: block.LabelRanges[0],
+				Config:    block.Body,
+				DeclRange: block.DefRange(),
+			}
+			if attr, ok := block.Body.Attributes["alias"]; ok {
+				provider.Alias, _ = quotedLiteral(attr.Expr)
+			}
+			cfg.Module.ProviderConfigs[ProviderAddr(provider)] = provider
+		}
+	}
+}
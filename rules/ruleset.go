@@ -0,0 +1,224 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	developmentVersion    = "dev"
+	referenceLinkTemplate = "https://github.com/dodopizza/tflint-ruleset-dodo/blob/%s/docs/rules/%s.md"
+)
+
+// Presets are named sets of enabled rules and their severities,
+// selected with the "preset" attribute of the plugin block.
+const (
+	PresetRecommended    = "recommended"
+	PresetStrict         = "strict"
+	PresetFormattingOnly = "formatting-only"
+)
+
+// formattingRules only check the layout of the code.
+var formattingRules = []string{
+	"dodo_attribute_alignment",
+	"dodo_comments",
+	"dodo_file_content",
+	"dodo_foreach_count",
+	"dodo_heredoc",
+	"dodo_legacy_syntax",
+	"dodo_line_length",
+	"dodo_locals_structure",
+}
+
+// recommendedWarningRules are reported as warnings by the recommended preset.
+var recommendedWarningRules = []string{
+	"dodo_attribute_alignment",
+	"dodo_heredoc",
+	"dodo_line_length",
+	"dodo_locals_structure",
+	"dodo_unused_declarations",
+}
+
+type ruleSettings struct {
+	enabled  bool
+	severity string
+}
+
+var presets = map[string]func(rule *Rule) ruleSettings{
+	PresetRecommended: func(rule *Rule) ruleSettings {
+		if containsString(recommendedWarningRules, rule.Name()) {
+			return ruleSettings{enabled: true, severity: tflint.WARNING}
+		}
+
+		return ruleSettings{enabled: true, severity: tflint.ERROR}
+	},
+	PresetStrict: func(rule *Rule) ruleSettings {
+		return ruleSettings{enabled: true, severity: tflint.ERROR}
+	},
+	PresetFormattingOnly: func(rule *Rule) ruleSettings {
+		return ruleSettings{
+			enabled:  containsString(formattingRules, rule.Name()),
+			severity: tflint.WARNING,
+		}
+	},
+}
+
+// NewRules returns all rules provided by the plugin.
+func NewRules() []*Rule {
+	return []*Rule{
+		NewBackendTypeRule(),
+		NewFileContentRule(),
+		NewCommentsRule(),
+		NewForeachCountRule(),
+		NewModuleStructureRule(),
+		NewAttributeAlignmentRule(),
+		NewLineLengthRule(),
+		NewLegacySyntaxRule(),
+		NewHeredocRule(),
+		NewTerraformRequirementsRule(),
+		NewModuleSourceRule(),
+		NewProviderInModuleRule(),
+		NewAzurermProviderRule(),
+		NewHardcodedSecretsRule(),
+		NewUnusedDeclarationsRule(),
+		NewLocalsStructureRule(),
+		NewLifecycleSafetyRule(),
+		NewDeprecationsRule(),
+		NewStorageAccountMinTLSRule(),
+		NewStorageAccountHTTPSOnlyRule(),
+		NewStorageAccountPublicAccessRule(),
+		NewWebAppHTTPSOnlyRule(),
+		NewKeyVaultPurgeProtectionRule(),
+		NewDatabasePublicNetworkAccessRule(),
+		NewNetworkSecurityRulesRule(),
+		NewRoleAssignmentsRule(),
+	}
+}
+
+// RuleSet is the plugin ruleset which applies presets and per-path
+// overrides from the plugin config:
+//
+//	plugin "dodo" {
+//	  preset = "strict"
+//
+//	  override {
+//	    files = ["legacy"]
+//
+//	    rule "dodo_comments" {
+//	      enabled = false
+//	    }
+//	  }
+//	}
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+
+	rules []*Rule
+}
+
+var _ tflint.RuleSet = &RuleSet{}
+
+func NewRuleSet(version string) *RuleSet {
+	ruleSet := &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    rulePrefix,
+			Version: version,
+		},
+		rules: NewRules(),
+	}
+
+	for _, rule := range ruleSet.rules {
+		rule.link = referenceLink(version, rule.Name())
+		ruleSet.Rules = append(ruleSet.Rules, rule)
+	}
+	ruleSet.applyPreset(presets[PresetRecommended])
+
+	return ruleSet
+}
+
+type pluginConfig struct {
+	Preset    string          `hcl:"preset,optional"`
+	Overrides []overrideBlock `hcl:"override,block"`
+	Remain    hcl.Body        `hcl:",remain"`
+}
+
+func (r *RuleSet) ApplyConfig(config *tflint.Config) error {
+	var cfg pluginConfig
+	if config.Body != nil {
+		if diags := gohcl.DecodeBody(config.Body, nil, &cfg); diags.HasErrors() {
+			return diags
+		}
+	}
+	preset := PresetRecommended
+	if cfg.Preset != "" {
+		preset = cfg.Preset
+	}
+
+	settings, ok := presets[preset]
+	if !ok {
+		return fmt.Errorf(
+			"unknown preset \"%s\", available presets: %s",
+			preset,
+			strings.Join(presetNames(), ", "),
+		)
+	}
+	r.applyPreset(settings)
+	r.ApplyCommonConfig(config)
+
+	enabledForPaths, err := r.applyOverrides(cfg.Overrides)
+	if err != nil {
+		return err
+	}
+	r.enableForPaths(enabledForPaths)
+
+	return nil
+}
+
+// enableForPaths adds rules disabled by the config, but enabled by
+// overrides, to enabled rules keeping the order of rules.
+func (r *RuleSet) enableForPaths(names map[string]bool) {
+	enabled := map[string]bool{}
+	for _, rule := range r.EnabledRules {
+		enabled[rule.Name()] = true
+	}
+
+	r.EnabledRules = []tflint.Rule{}
+	for _, rule := range r.rules {
+		rule.pathsOnly = !enabled[rule.Name()] && names[rule.Name()]
+		if enabled[rule.Name()] || rule.pathsOnly {
+			r.EnabledRules = append(r.EnabledRules, rule)
+		}
+	}
+}
+
+func (r *RuleSet) applyPreset(settings func(rule *Rule) ruleSettings) {
+	for _, rule := range r.rules {
+		s := settings(rule)
+		rule.enabled = s.enabled
+		rule.severity = s.severity
+	}
+}
+
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// referenceLink points to the rule documentation of the released version.
+func referenceLink(version, name string) string {
+	ref := "main"
+	if version != developmentVersion {
+		ref = "v" + strings.TrimPrefix(version, "v")
+	}
+
+	return fmt.Sprintf(referenceLinkTemplate, ref, name)
+}
@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_RuleSet(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name       string
+		Body       string
+		Rules      map[string]*tflint.RuleConfig
+		Enabled    int
+		Severities map[string]string
+		Error      bool
+	}{
+		{
+			Name:    "default preset",
+			Body:    ``,
+			Enabled: len(NewRules()),
+			Severities: map[string]string{
+				"dodo_comments":    tflint.ERROR,
+				"dodo_line_length": tflint.WARNING,
+			},
+		},
+		{
+			Name:    "strict preset",
+			Body:    `preset = "strict"`,
+			Enabled: len(NewRules()),
+			Severities: map[string]string{
+				"dodo_comments":    tflint.ERROR,
+				"dodo_line_length": tflint.ERROR,
+			},
+		},
+		{
+			Name: "formatting-only preset with rule override",
+			Body: `preset = "formatting-only"`,
+			Rules: map[string]*tflint.RuleConfig{
+				"dodo_comments": {Name: "dodo_comments", Enabled: false},
+			},
+			Enabled: len(formattingRules) - 1,
+			Severities: map[string]string{
+				"dodo_line_length": tflint.WARNING,
+			},
+		},
+		{
+			Name:  "unknown preset",
+			Body:  `preset = "unknown"`,
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			file, diags := hclsyntax.ParseConfig([]byte(tc.Body), "plugin.hcl", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags)
+
+			ruleSet := NewRuleSet("0.2.0")
+			err := ruleSet.ApplyConfig(&tflint.Config{Rules: tc.Rules, Body: file.Body})
+			if tc.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, ruleSet.EnabledRules, tc.Enabled)
+			for _, rule := range ruleSet.EnabledRules {
+				if severity, ok := tc.Severities[rule.Name()]; ok {
+					require.Equal(t, severity, rule.Severity(), rule.Name())
+				}
+				require.Equal(
+					t,
+					"https://github.com/dodopizza/tflint-ruleset-dodo/blob/v0.2.0/docs/rules/"+rule.Name()+".md",
+					rule.Link(),
+				)
+			}
+		})
+	}
+}
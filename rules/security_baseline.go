@@ -0,0 +1,304 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+const allowPublicNetworkAccessAnnotation = "allow-public-network-access"
+
+const (
+	securitySettingMissingMessageTemplate = "Resource \"%s\" should set %s = %s"
+	securitySettingValueMessageTemplate   = "Resource \"%s\" should set %s = %s instead of %s"
+)
+
+var webAppResourceTypes = []string{
+	"azurerm_app_service",
+	"azurerm_app_service_slot",
+	"azurerm_function_app",
+	"azurerm_function_app_slot",
+	"azurerm_linux_function_app",
+	"azurerm_linux_function_app_slot",
+	"azurerm_linux_web_app",
+	"azurerm_linux_web_app_slot",
+	"azurerm_windows_function_app",
+	"azurerm_windows_function_app_slot",
+	"azurerm_windows_web_app",
+	"azurerm_windows_web_app_slot",
+}
+
+var databaseResourceTypes = []string{
+	"azurerm_cosmosdb_account",
+	"azurerm_mariadb_server",
+	"azurerm_mssql_server",
+	"azurerm_mysql_flexible_server",
+	"azurerm_mysql_server",
+	"azurerm_postgresql_flexible_server",
+	"azurerm_postgresql_server",
+}
+
+// securitySetting is a resource argument which must be set to a secure
+// value explicitly, not to rely on provider defaults.
+type securitySetting struct {
+	// names are alternative names of the argument in provider versions,
+	// the first one is suggested.
+	names []string
+	// values are allowed values, the first one is suggested.
+	values []cty.Value
+	// annotation opts resources out of the check, if not empty.
+	annotation string
+}
+
+type securityBaselineRuleConfig struct {
+	ResourceTypes []string `hcl:"resource_types,optional"`
+}
+
+func NewStorageAccountMinTLSRule() *Rule {
+	return newSecurityBaselineRule(
+		"storage_account_min_tls",
+		Documentation{
+			Description: "Check that storage accounts set `min_tls_version = \"TLS1_2\"`",
+			Example: `resource "azurerm_storage_account" "example" {
+  min_tls_version = "TLS1_0"
+}
+`,
+			Config: `resource_types = ["azurerm_storage_account"]
+`,
+		},
+		[]string{"azurerm_storage_account"},
+		securitySetting{
+			names:  []string{"min_tls_version"},
+			values: []cty.Value{cty.StringVal("TLS1_2"), cty.StringVal("TLS1_3")},
+		},
+	)
+}
+
+func NewStorageAccountHTTPSOnlyRule() *Rule {
+	return newSecurityBaselineRule(
+		"storage_account_https_only",
+		Documentation{
+			Description: "Check that storage accounts set `https_traffic_only_enabled = true` or " +
+				"`enable_https_traffic_only = true` with older provider versions",
+			Example: `resource "azurerm_storage_account" "example" {
+  enable_https_traffic_only = false
+}
+`,
+			Config: `resource_types = ["azurerm_storage_account"]
+`,
+		},
+		[]string{"azurerm_storage_account"},
+		securitySetting{
+			names:  []string{"https_traffic_only_enabled", "enable_https_traffic_only"},
+			values: []cty.Value{cty.True},
+		},
+	)
+}
+
+func NewStorageAccountPublicAccessRule() *Rule {
+	return newSecurityBaselineRule(
+		"storage_account_public_access",
+		Documentation{
+			Description: "Check that storage accounts set `allow_nested_items_to_be_public = false`",
+			Example: `resource "azurerm_storage_account" "example" {
+  allow_nested_items_to_be_public = true
+}
+`,
+			Config: `resource_types = ["azurerm_storage_account"]
+`,
+		},
+		[]string{"azurerm_storage_account"},
+		securitySetting{
+			names:  []string{"allow_nested_items_to_be_public"},
+			values: []cty.Value{cty.False},
+		},
+	)
+}
+
+func NewWebAppHTTPSOnlyRule() *Rule {
+	return newSecurityBaselineRule(
+		"web_app_https_only",
+		Documentation{
+			Description: "Check that web and function apps set `https_only = true`",
+			Example: `resource "azurerm_linux_web_app" "example" {
+  name = "example"
+}
+`,
+			Config: `resource_types = ["azurerm_linux_web_app", "azurerm_windows_web_app"]
+`,
+		},
+		webAppResourceTypes,
+		securitySetting{
+			names:  []string{"https_only"},
+			values: []cty.Value{cty.True},
+		},
+	)
+}
+
+func NewKeyVaultPurgeProtectionRule() *Rule {
+	return newSecurityBaselineRule(
+		"key_vault_purge_protection",
+		Documentation{
+			Description: "Check that key vaults set `purge_protection_enabled = true`",
+			Example: `resource "azurerm_key_vault" "example" {
+  name = "example"
+}
+`,
+			Config: `resource_types = ["azurerm_key_vault"]
+`,
+		},
+		[]string{"azurerm_key_vault"},
+		securitySetting{
+			names:  []string{"purge_protection_enabled"},
+			values: []cty.Value{cty.True},
+		},
+	)
+}
+
+func NewDatabasePublicNetworkAccessRule() *Rule {
+	return newSecurityBaselineRule(
+		"database_public_network_access",
+		Documentation{
+			Description: "Check that databases set `public_network_access_enabled = false` unless annotated with " +
+				"`// dodo:allow-public-network-access`",
+			Example: `resource "azurerm_mssql_server" "example" {
+  public_network_access_enabled = true
+}
+`,
+			Config: `resource_types = ["azurerm_mssql_server", "azurerm_redis_cache"]
+`,
+		},
+		databaseResourceTypes,
+		securitySetting{
+			names:      []string{"public_network_access_enabled"},
+			values:     []cty.Value{cty.False},
+			annotation: allowPublicNetworkAccessAnnotation,
+		},
+	)
+}
+
+// newSecurityBaselineRule returns a rule checking the setting of resources
+// of the types, which can be changed with resource_types option.
+func newSecurityBaselineRule(
+	name string,
+	doc Documentation,
+	resourceTypes []string,
+	setting securitySetting,
+) *Rule {
+	return NewRule(
+		name,
+		doc,
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := securityBaselineRuleConfig{ResourceTypes: resourceTypes}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			annotated := map[string]map[int]bool{}
+			if setting.annotation != "" {
+				var err error
+				if annotated, err = getAnnotatedLines(runner, setting.annotation); err != nil {
+					return err
+				}
+			}
+
+			cfg, err := runner.Config()
+			if err != nil {
+				return err
+			}
+
+			addrs := make([]string, 0, len(cfg.Module.ManagedResources))
+			for addr, res := range cfg.Module.ManagedResources {
+				if containsString(config.ResourceTypes, res.Type) {
+					addrs = append(addrs, addr)
+				}
+			}
+			sort.Strings(addrs)
+
+			for _, addr := range addrs {
+				res := cfg.Module.ManagedResources[addr]
+				if annotated[res.DeclRange.Filename][res.DeclRange.Start.Line] {
+					continue
+				}
+				if err := checkSecuritySetting(runner, rule, setting, addr, res); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+func checkSecuritySetting(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	setting securitySetting,
+	addr string,
+	res *configs.Resource,
+) error {
+	schema := &hcl.BodySchema{}
+	for _, name := range setting.names {
+		schema.Attributes = append(schema.Attributes, hcl.AttributeSchema{Name: name})
+	}
+	content, _, diags := res.Config.PartialContent(schema)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	suggested := setting.values[0]
+	for _, name := range setting.names {
+		attr, ok := content.Attributes[name]
+		if !ok {
+			continue
+		}
+
+		// Values the runner cannot evaluate, e.g. resource attributes, are trusted.
+		var val cty.Value
+		ok, err := evaluateExpr(runner, attr.Expr, &val, cty.DynamicPseudoType)
+		if err != nil || !ok || isSecureValue(val, setting.values) {
+			return err
+		}
+
+		return runner.EmitIssueOnExpr(
+			rule,
+			fmt.Sprintf(
+				securitySettingValueMessageTemplate,
+				addr,
+				name,
+				formatValue(suggested),
+				formatValue(val),
+			),
+			attr.Expr,
+		)
+	}
+
+	return runner.EmitIssue(
+		rule,
+		fmt.Sprintf(securitySettingMissingMessageTemplate, addr, setting.names[0], formatValue(suggested)),
+		res.DeclRange,
+	)
+}
+
+func isSecureValue(val cty.Value, values []cty.Value) bool {
+	for _, secure := range values {
+		converted, err := convert.Convert(val, secure.Type())
+		if err == nil && !converted.IsNull() && converted.Equals(secure).True() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatValue renders the value as HCL literal.
+func formatValue(val cty.Value) string {
+	return strings.TrimSpace(string(hclwrite.TokensForValue(val).Bytes()))
+}
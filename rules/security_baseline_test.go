@@ -0,0 +1,311 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_SecurityBaseline(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Rule     *Rule
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "storage account with secure settings",
+			Rule: NewStorageAccountMinTLSRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_storage_account" "test" {
+  min_tls_version = "TLS1_2"
+}
+
+variable "min_tls_version" {}
+
+resource "azurerm_storage_account" "variable" {
+  min_tls_version = var.min_tls_version
+}
+
+resource "azurerm_storage_account" "reference" {
+  min_tls_version = azurerm_storage_account.test.min_tls_version
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "storage account with insecure TLS version",
+			Rule: NewStorageAccountMinTLSRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_storage_account" "test" {
+  min_tls_version = "TLS1_0"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewStorageAccountMinTLSRule(),
+					Message: `Resource "azurerm_storage_account.test" should set min_tls_version = "TLS1_2" instead of "TLS1_0"`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 21},
+						End:      hcl.Pos{Line: 2, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name: "insecure values from variable defaults and locals",
+			Rule: NewStorageAccountMinTLSRule(),
+			Content: map[string]string{
+				filename: `variable "min_tls_version" {
+  default = "TLS1_0"
+}
+
+locals {
+  tls = "TLS1_1"
+}
+
+resource "azurerm_storage_account" "variable" {
+  min_tls_version = var.min_tls_version
+}
+
+resource "azurerm_storage_account" "local" {
+  min_tls_version = local.tls
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewStorageAccountMinTLSRule(),
+					Message: `Resource "azurerm_storage_account.local" should set min_tls_version = "TLS1_2" instead of "TLS1_1"`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 14, Column: 21},
+						End:      hcl.Pos{Line: 14, Column: 30},
+					},
+				},
+				{
+					Rule:    NewStorageAccountMinTLSRule(),
+					Message: `Resource "azurerm_storage_account.variable" should set min_tls_version = "TLS1_2" instead of "TLS1_0"`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 10, Column: 21},
+						End:      hcl.Pos{Line: 10, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "web app with https_only from variable default",
+			Rule: NewWebAppHTTPSOnlyRule(),
+			Content: map[string]string{
+				filename: `variable "https_only" {
+  default = false
+}
+
+resource "azurerm_app_service" "test" {
+  https_only = var.https_only
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewWebAppHTTPSOnlyRule(),
+					Message: `Resource "azurerm_app_service.test" should set https_only = true instead of false`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 6, Column: 16},
+						End:      hcl.Pos{Line: 6, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "storage account with legacy https argument",
+			Rule: NewStorageAccountHTTPSOnlyRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_storage_account" "legacy" {
+  enable_https_traffic_only = true
+}
+
+resource "azurerm_storage_account" "disabled" {
+  https_traffic_only_enabled = false
+}
+
+resource "azurerm_storage_account" "missing" {
+  name = "missing"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewStorageAccountHTTPSOnlyRule(),
+					Message: `Resource "azurerm_storage_account.disabled" ` +
+						`should set https_traffic_only_enabled = true instead of false`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 6, Column: 32},
+						End:      hcl.Pos{Line: 6, Column: 37},
+					},
+				},
+				{
+					Rule:    NewStorageAccountHTTPSOnlyRule(),
+					Message: `Resource "azurerm_storage_account.missing" should set https_traffic_only_enabled = true`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 9, Column: 1},
+						End:      hcl.Pos{Line: 9, Column: 45},
+					},
+				},
+			},
+		},
+		{
+			Name: "storage account with public nested items",
+			Rule: NewStorageAccountPublicAccessRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_storage_account" "test" {
+  allow_nested_items_to_be_public = true
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule: NewStorageAccountPublicAccessRule(),
+					Message: `Resource "azurerm_storage_account.test" ` +
+						`should set allow_nested_items_to_be_public = false instead of true`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 37},
+						End:      hcl.Pos{Line: 2, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "web app without https_only",
+			Rule: NewWebAppHTTPSOnlyRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_linux_web_app" "test" {
+  name = "test"
+}
+
+resource "azurerm_windows_function_app" "test" {
+  https_only = true
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewWebAppHTTPSOnlyRule(),
+					Message: `Resource "azurerm_linux_web_app.test" should set https_only = true`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "key vault without purge protection",
+			Rule: NewKeyVaultPurgeProtectionRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_key_vault" "test" {
+  purge_protection_enabled = false
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKeyVaultPurgeProtectionRule(),
+					Message: `Resource "azurerm_key_vault.test" should set purge_protection_enabled = true instead of false`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 30},
+						End:      hcl.Pos{Line: 2, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "databases with public network access",
+			Rule: NewDatabasePublicNetworkAccessRule(),
+			Content: map[string]string{
+				filename: `resource "azurerm_mssql_server" "test" {
+  public_network_access_enabled = true
+}
+
+// Reporting tools connect from the office network.
+// dodo:allow-public-network-access
+resource "azurerm_postgresql_flexible_server" "test" {
+  public_network_access_enabled = true
+}
+
+resource "azurerm_cosmosdb_account" "test" {
+  public_network_access_enabled = false
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewDatabasePublicNetworkAccessRule(),
+					Message: `Resource "azurerm_mssql_server.test" should set public_network_access_enabled = false instead of true`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 35},
+						End:      hcl.Pos{Line: 2, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured resource types",
+			Rule: NewDatabasePublicNetworkAccessRule(),
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_database_public_network_access" {
+  enabled        = true
+  resource_types = ["azurerm_redis_cache"]
+}
+`,
+				filename: `resource "azurerm_mssql_server" "test" {
+  public_network_access_enabled = true
+}
+
+resource "azurerm_redis_cache" "test" {
+  name = "test"
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewDatabasePublicNetworkAccessRule(),
+					Message: `Resource "azurerm_redis_cache.test" should set public_network_access_enabled = false`,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newEvalRunner(t, tc.Content)
+
+			require.NoError(t, tc.Rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// builtinProvider is available without declaration in required_providers.
+const builtinProvider = "terraform"
+
+const (
+	requiredVersionMissingMessage             = "terraform block should declare required_version"
+	requiredVersionUpperBoundMessageTemplate  = "required_version \"%s\" should have an upper bound"
+	requiredProviderMissingMessageTemplate    = "Provider \"%s\" should be declared in required_providers"
+	requiredProviderSourceMessageTemplate     = "Provider \"%s\" in required_providers should declare source"
+	requiredProviderVersionMessageTemplate    = "Provider \"%s\" in required_providers should declare version constraint"
+	requiredProviderUpperBoundMessageTemplate = "Version constraint \"%s\" of provider \"%s\" should have an upper bound"
+)
+
+func NewTerraformRequirementsRule() *Rule {
+	return NewRule(
+		"terraform_requirements",
+		Documentation{
+			Description: "Check that root modules pin `required_version` with an upper bound and that every used " +
+				"provider is declared in `required_providers` with `source` and bounded version constraint. " +
+				"Modules are root ones when they configure a backend and are not placed into `modules/` directory, " +
+				"other modules are reusable and may use open-ended constraints",
+			Example: `terraform {
+  required_version = ">= 1.0"
+
+  backend "azurerm" {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "example"
+}
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			settings, err := getTerraformSettings(runner)
+			if err != nil {
+				return err
+			}
+			root, err := isRootModule(runner)
+			if err != nil {
+				return err
+			}
+
+			if root {
+				if err := checkRequiredVersion(runner, rule, settings); err != nil {
+					return err
+				}
+			}
+
+			return checkRequiredProviders(runner, rule, settings, root)
+		},
+	)
+}
+
+func checkRequiredVersion(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	settings *terraformSettings,
+) error {
+	if settings.block == nil {
+		return nil
+	}
+
+	if settings.requiredVersion == nil {
+		return runner.EmitIssue(
+			rule,
+			requiredVersionMissingMessage,
+			settings.block.DefRange(),
+		)
+	}
+
+	version, ok := quotedLiteral(settings.requiredVersion.Expr)
+	if ok && !hasUpperBound(version) {
+		return runner.EmitIssue(
+			rule,
+			fmt.Sprintf(requiredVersionUpperBoundMessageTemplate, version),
+			settings.requiredVersion.Expr.Range(),
+		)
+	}
+
+	return nil
+}
+
+func checkRequiredProviders(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	settings *terraformSettings,
+	root bool,
+) error {
+	used, err := getUsedProviders(runner)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := settings.providers[name]; ok || name == builtinProvider {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			rule,
+			fmt.Sprintf(requiredProviderMissingMessageTemplate, name),
+			used[name],
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, provider := range settings.sortedProviders() {
+		if provider.source == "" {
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(requiredProviderSourceMessageTemplate, provider.name),
+				provider.declRange,
+			); err != nil {
+				return err
+			}
+		}
+
+		if provider.version == "" {
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(requiredProviderVersionMessageTemplate, provider.name),
+				provider.declRange,
+			); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if root && !hasUpperBound(provider.version) {
+			if err := runner.EmitIssue(
+				rule,
+				fmt.Sprintf(requiredProviderUpperBoundMessageTemplate, provider.version, provider.name),
+				provider.declRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// terraformSettings is a merged content of all terraform blocks of the module.
+type terraformSettings struct {
+	block           *hclsyntax.Block
+	requiredVersion *hclsyntax.Attribute
+	providers       map[string]*requiredProvider
+}
+
+type requiredProvider struct {
+	name      string
+	source    string
+	version   string
+	aliases   []string
+	declRange hcl.Range
+}
+
+func (s *terraformSettings) sortedProviders() []*requiredProvider {
+	providers := make([]*requiredProvider, 0, len(s.providers))
+	for _, provider := range s.providers {
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].name < providers[j].name
+	})
+
+	return providers
+}
+
+func getTerraformSettings(runner tflint.Runner) (*terraformSettings, error) {
+	files, err := runner.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &terraformSettings{providers: map[string]*requiredProvider{}}
+	for _, filename := range SortedFilenames(files) {
+		body, ok := files[filename].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			if settings.block == nil {
+				settings.block = block
+			}
+			if attr, ok := block.Body.Attributes["required_version"]; ok {
+				settings.requiredVersion = attr
+			}
+
+			for _, nested := range block.Body.Blocks {
+				if nested.Type != "required_providers" {
+					continue
+				}
+				for _, attr := range sortedAttributes(nested.Body) {
+					settings.providers[attr.Name] = decodeRequiredProvider(attr)
+				}
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+func decodeRequiredProvider(attr *hclsyntax.Attribute) *requiredProvider {
+	provider := &requiredProvider{
+		name:      attr.Name,
+		declRange: attr.Range(),
+	}
+
+	// Legacy form with version constraint only.
+	if version, ok := quotedLiteral(attr.Expr); ok {
+		provider.version = version
+		return provider
+	}
+
+	pairs, diags := hcl.ExprMap(attr.Expr)
+	if diags.HasErrors() {
+		return provider
+	}
+	for _, pair := range pairs {
+		switch hcl.ExprAsKeyword(pair.Key) {
+		case "source":
+			provider.source, _ = quotedLiteral(pair.Value)
+		case "version":
+			provider.version, _ = quotedLiteral(pair.Value)
+		case "configuration_aliases":
+			aliases, _ := hcl.ExprList(pair.Value)
+			for _, alias := range aliases {
+				traversal, diags := hcl.AbsTraversalForExpr(alias)
+				if diags.HasErrors() || len(traversal) != 2 {
+					continue
+				}
+				if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+					provider.aliases = append(provider.aliases, attr.Name)
+				}
+			}
+		}
+	}
+
+	return provider
+}
+
+// getUsedProviders returns providers used by resources, data sources and
+// provider blocks of the module with a range of the first usage.
+func getUsedProviders(runner tflint.Runner) (map[string]hcl.Range, error) {
+	files, err := runner.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]hcl.Range{}
+	for _, filename := range SortedFilenames(files) {
+		body, ok := files[filename].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			var name string
+			switch block.Type {
+			case "resource", "data":
+				name = resourceProviderName(block)
+			case "provider":
+				name = block.Labels[0]
+			default:
+				continue
+			}
+
+			if _, ok := used[name]; !ok {
+				used[name] = block.DefRange()
+			}
+		}
+	}
+
+	return used, nil
+}
+
+// resourceProviderName returns a provider local name of the resource,
+// either from the provider meta-argument or from the resource type prefix.
+func resourceProviderName(block *hclsyntax.Block) string {
+	if attr, ok := block.Body.Attributes["provider"]; ok {
+		traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+		if !diags.HasErrors() {
+			return traversal.RootName()
+		}
+	}
+
+	return strings.SplitN(block.Labels[0], "_", 2)[0]
+}
+
+// hasUpperBound reports whether the version constraint limits the maximum
+// version, i.e. it is not built only from ">", ">=" and "!=" operators.
+func hasUpperBound(constraint string) bool {
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" ||
+			strings.HasPrefix(part, ">") ||
+			strings.HasPrefix(part, "!=") {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
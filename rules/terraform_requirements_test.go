@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformRequirements(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: `terraform {
+  required_version = "~> 1.1"
+
+  backend "azurerm" {}
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = ">= 2.90, < 3.0"
+    }
+  }
+}
+
+resource "azurerm_resource_group" "test" {
+  name = "test"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no issues with open-ended constraint in child module",
+			Content: `terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = ">= 2.90"
+    }
+  }
+}
+
+resource "azurerm_resource_group" "test" {
+  name = "test"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no required_version",
+			Content: `terraform {
+  backend "azurerm" {}
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequirementsRule(),
+					Message: requiredVersionMissingMessage,
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "required_version without upper bound",
+			Content: `terraform {
+  required_version = ">= 1.0"
+
+  backend "azurerm" {}
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequirementsRule(),
+					Message: fmt.Sprintf(requiredVersionUpperBoundMessageTemplate, ">= 1.0"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 22},
+						End:      hcl.Pos{Line: 2, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "provider is not declared",
+			Content: `resource "null_resource" "test" {
+  name = "test"
+}
+
+data "terraform_remote_state" "test" {
+  backend = "azurerm"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequirementsRule(),
+					Message: fmt.Sprintf(requiredProviderMissingMessageTemplate, "null"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "legacy and open-ended provider requirements in root module",
+			Content: `terraform {
+  required_version = "~> 1.1"
+
+  backend "azurerm" {}
+
+  required_providers {
+    azurerm = ">= 2.90"
+    null = {
+      source = "hashicorp/null"
+    }
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequirementsRule(),
+					Message: fmt.Sprintf(requiredProviderSourceMessageTemplate, "azurerm"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 7, Column: 5},
+						End:      hcl.Pos{Line: 7, Column: 24},
+					},
+				},
+				{
+					Rule:    NewTerraformRequirementsRule(),
+					Message: fmt.Sprintf(requiredProviderUpperBoundMessageTemplate, ">= 2.90", "azurerm"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 7, Column: 5},
+						End:      hcl.Pos{Line: 7, Column: 24},
+					},
+				},
+				{
+					Rule:    NewTerraformRequirementsRule(),
+					Message: fmt.Sprintf(requiredProviderVersionMessageTemplate, "null"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 8, Column: 5},
+						End:      hcl.Pos{Line: 10, Column: 6},
+					},
+				},
+			},
+		},
+	}
+	rule := NewTerraformRequirementsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content})
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
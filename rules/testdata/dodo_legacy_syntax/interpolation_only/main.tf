@@ -0,0 +1,4 @@
+resource "azurerm_resource_group" "example" {
+  name     = "${var.name}" // want "Interpolation-only expressions are deprecated, use the expression without \"${}\""
+  location = "${var.location}-2" # Template with other parts is fine.
+}
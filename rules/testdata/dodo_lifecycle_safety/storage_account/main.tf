@@ -0,0 +1,16 @@
+resource "azurerm_storage_account" "unprotected" { // want "Resource \"azurerm_storage_account.unprotected\" holds data and should have lifecycle prevent_destroy = true"
+  name = "unprotected"
+}
+
+resource "azurerm_storage_account" "protected" {
+  name = "protected"
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+// dodo:allow-destroy
+resource "azurerm_storage_account" "temporary" {
+  name = "temporary"
+}
@@ -0,0 +1,4 @@
+rule "dodo_line_length" {
+  enabled = true
+  max     = 40
+}
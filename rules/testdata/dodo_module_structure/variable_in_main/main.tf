@@ -0,0 +1,5 @@
+variable "name" {}
+
+resource "azurerm_resource_group" "example" {
+  name = var.name
+}
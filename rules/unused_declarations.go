@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const unusedDeclarationMessageTemplate = "%s \"%s\" is declared but not used"
+
+type unusedDeclarationsRuleConfig struct {
+	IgnoredVariables []string `hcl:"ignored_variables,optional"`
+}
+
+type declaration struct {
+	kind string
+	name string
+	rng  hcl.Range
+}
+
+func NewUnusedDeclarationsRule() *Rule {
+	return NewRule(
+		"unused_declarations",
+		Documentation{
+			Description: "Check that all declared variables, locals and data sources are referenced",
+			Example: `variable "unused" {}
+
+data "azurerm_client_config" "current" {}
+`,
+			Config: `ignored_variables = ["environment"]
+`,
+		},
+		func(runner tflint.Runner, rule tflint.Rule) error {
+			config := unusedDeclarationsRuleConfig{}
+			if err := runner.DecodeRuleConfig(rule.Name(), &config); err != nil {
+				return err
+			}
+
+			files, err := runner.Files()
+			if err != nil {
+				return err
+			}
+
+			declarations := []declaration{}
+			references := map[string]bool{}
+			for _, filename := range SortedFilenames(files) {
+				body, ok := files[filename].Body.(*hclsyntax.Body)
+				if !ok {
+					continue
+				}
+
+				for _, block := range body.Blocks {
+					switch block.Type {
+					case "variable":
+						if !containsString(config.IgnoredVariables, block.Labels[0]) {
+							declarations = append(declarations, declaration{
+								kind: "Variable",
+								name: "var." + block.Labels[0],
+								rng:  block.DefRange(),
+							})
+						}
+						// Variable can reference only itself in validation rules.
+						continue
+					case "locals":
+						for _, attr := range sortedAttributes(block.Body) {
+							declarations = append(declarations, declaration{
+								kind: "Local value",
+								name: "local." + attr.Name,
+								rng:  attr.SrcRange,
+							})
+						}
+					case "data":
+						declarations = append(declarations, declaration{
+							kind: "Data source",
+							name: fmt.Sprintf("data.%s.%s", block.Labels[0], block.Labels[1]),
+							rng:  block.DefRange(),
+						})
+					}
+
+					collectReferences(block.Body, references)
+				}
+			}
+
+			for _, decl := range declarations {
+				if references[decl.name] {
+					continue
+				}
+
+				if err := runner.EmitIssue(
+					rule,
+					fmt.Sprintf(unusedDeclarationMessageTemplate, decl.kind, decl.name),
+					decl.rng,
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+// collectReferences adds addresses of variables, locals and data sources
+// referenced from all expressions of the body to references.
+func collectReferences(body *hclsyntax.Body, references map[string]bool) {
+	for _, attr := range body.Attributes {
+		for _, traversal := range attr.Expr.Variables() {
+			if addr, ok := referenceAddr(traversal); ok {
+				references[addr] = true
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		collectReferences(block.Body, references)
+	}
+}
+
+func referenceAddr(traversal hcl.Traversal) (string, bool) {
+	names := []string{traversal.RootName()}
+	for _, step := range traversal[1:] {
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			break
+		}
+		names = append(names, attr.Name)
+	}
+
+	switch {
+	case (names[0] == "var" || names[0] == "local") && len(names) >= 2:
+		return names[0] + "." + names[1], true
+	case names[0] == "data" && len(names) >= 3:
+		return fmt.Sprintf("data.%s.%s", names[1], names[2]), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_UnusedDeclarations(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no issues",
+			Content: map[string]string{
+				variablesFilename: `variable "name" {
+  type = string
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "Name should not be empty."
+  }
+}
+
+variable "location" {}
+`,
+				filename: `locals {
+  tags = {
+    location = var.location
+  }
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name      = "${var.name}-rg"
+  tenant_id = data.azurerm_client_config.current.tenant_id
+
+  dynamic "tag" {
+    for_each = local.tags
+
+    content {
+      key = tag.key
+    }
+  }
+}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unused declarations",
+			Content: map[string]string{
+				variablesFilename: `variable "name" {
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "Name should not be empty."
+  }
+}
+`,
+				filename: `locals {
+  unused = "test"
+}
+
+data "azurerm_client_config" "current" {}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewUnusedDeclarationsRule(),
+					Message: fmt.Sprintf(unusedDeclarationMessageTemplate, "Local value", "local.unused"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 18},
+					},
+				},
+				{
+					Rule:    NewUnusedDeclarationsRule(),
+					Message: fmt.Sprintf(unusedDeclarationMessageTemplate, "Data source", "data.azurerm_client_config.current"),
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 39},
+					},
+				},
+				{
+					Rule:    NewUnusedDeclarationsRule(),
+					Message: fmt.Sprintf(unusedDeclarationMessageTemplate, "Variable", "var.name"),
+					Range: hcl.Range{
+						Filename: variablesFilename,
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "ignored variables",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "dodo_unused_declarations" {
+  enabled           = true
+  ignored_variables = ["environment"]
+}
+`,
+				variablesFilename: `variable "environment" {}
+`,
+			},
+			Expected: helper.Issues{},
+		},
+	}
+	rule := NewUnusedDeclarationsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := newSyntaxRunner(t, tc.Content)
+
+			require.NoError(t, rule.Check(runner))
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"os"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/configs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// EvaluableRoots are roots of references the runner evaluates,
+// variables, local values and the workspace.
+var EvaluableRoots = []string{"var", "local", "terraform"}
+
+// EvalContext returns the context evaluating expressions of the module
+// like TFLint does. Variables take their defaults and are unknown without
+// them, local values referencing anything but evaluable roots are unknown.
+func EvalContext(module *configs.Module) *hcl.EvalContext {
+	variables := map[string]cty.Value{}
+	for name, variable := range module.Variables {
+		variables[name] = variable.Default
+		if variable.Default == cty.NilVal {
+			variables[name] = cty.DynamicVal
+		}
+	}
+
+	workspace, ok := os.LookupEnv("TF_WORKSPACE")
+	if !ok {
+		workspace = "default"
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(variables),
+			"terraform": cty.ObjectVal(map[string]cty.Value{
+				"workspace": cty.StringVal(workspace),
+			}),
+		},
+	}
+
+	names := make([]string, 0, len(module.Locals))
+	locals := map[string]cty.Value{}
+	for name := range module.Locals {
+		names = append(names, name)
+		locals[name] = cty.DynamicVal
+	}
+	sort.Strings(names)
+
+	// Local values may reference each other, every pass resolves at least
+	// one more level of references.
+	for range names {
+		ctx.Variables["local"] = cty.ObjectVal(locals)
+		next := map[string]cty.Value{}
+		for _, name := range names {
+			next[name] = cty.DynamicVal
+			if val, diags := module.Locals[name].Expr.Value(ctx); !diags.HasErrors() {
+				next[name] = val
+			}
+		}
+		locals = next
+	}
+	ctx.Variables["local"] = cty.ObjectVal(locals)
+
+	return ctx
+}
+
+// evaluateExpr evaluates the expression with the runner, so variables take
+// values from tfvars files and -var flags under TFLint. It reports false
+// without an error if the expression references anything but variables and
+// local values, e.g. resource attributes, or its value is unknown or null.
+func evaluateExpr(runner tflint.Runner, expr hcl.Expression, ret interface{}, wantType cty.Type) (bool, error) {
+	for _, traversal := range expr.Variables() {
+		if !containsString(EvaluableRoots, traversal.RootName()) {
+			return false, nil
+		}
+	}
+
+	evaluated := false
+	err := runner.EnsureNoError(runner.EvaluateExpr(expr, ret, &wantType), func() error {
+		evaluated = true
+		return nil
+	})
+
+	return evaluated, err
+}
+
+// attributeString returns the evaluated value of the attribute holding
+// a string, numbers are converted like Terraform does.
+func attributeString(runner tflint.Runner, body *hclsyntax.Body, name string) (string, bool, error) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return "", false, nil
+	}
+
+	var value string
+	ok, err := evaluateExpr(runner, attr.Expr, &value, cty.String)
+
+	return value, ok, err
+}
+
+// attributeStringList returns the evaluated value of the attribute
+// holding a list of strings.
+func attributeStringList(runner tflint.Runner, body *hclsyntax.Body, name string) ([]string, bool, error) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var values []string
+	ok, err := evaluateExpr(runner, attr.Expr, &values, cty.List(cty.String))
+
+	return values, ok, err
+}
@@ -0,0 +1,43 @@
+// Docgen renders docs/rules/<name>.md files and the rules table of README.md
+// from the documentation of registered rules. Run it with "go generate ./..."
+// from the repository root.
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dodopizza/tflint-ruleset-dodo/rules"
+)
+
+func main() {
+	docs, err := rules.GenerateDocs(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Remove docs of deleted and renamed rules.
+	stale, err := filepath.Glob(filepath.Join(rules.DocsDir, "*.md"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, filename := range stale {
+		if _, ok := docs[filepath.ToSlash(filename)]; !ok {
+			if err := os.Remove(filename); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(rules.DocsDir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	for filename, content := range docs {
+		if err := os.WriteFile(filepath.FromSlash(filename), content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("generated %s", strings.TrimPrefix(filename, "./"))
+	}
+}